@@ -0,0 +1,127 @@
+package Emitter
+
+import (
+	"sort"
+	"sync/atomic"
+)
+
+// ConflictPolicy - what Absorb does with the absorbed listeners whose function is already registered on the event
+type ConflictPolicy int
+
+const (
+	// KeepBoth - register the absorbed listener besides the existing one, the default
+	KeepBoth ConflictPolicy = iota
+	// KeepExisting - drop the absorbed listener
+	KeepExisting
+	// KeepAbsorbed - replace the existing listener with the absorbed one
+	KeepAbsorbed
+)
+
+// Absorb() - move all the listeners of the other emitter into this one, atomically,
+// the subscriptions of the other emitter don't control the moved listeners anymore
+func (self *Emitter) Absorb(other *Emitter) *Emitter {
+	return self.AbsorbWith(other, KeepBoth)
+}
+
+// AbsorbWith() - move all the listeners of the other emitter into this one resolving the conflicts with the
+// policy; the listeners on the events this one rejects (reserved, unauthorized ...) stay in the other one,
+// each rejection being reported as a registration on this one is
+func (self *Emitter) AbsorbWith(other *Emitter, policy ConflictPolicy) *Emitter {
+	if other == self {
+		return self
+	}
+
+	// the events of the other emitter, checked without holding the locks as the auth callback may use the emitters
+	other.mutex.Lock()
+	events := make([]string, 0, len(other.listeners))
+	for event := range other.listeners {
+		events = append(events, event.(string))
+	}
+	other.mutex.Unlock()
+	allowed := make(map[string]bool, len(events))
+	for _, event := range events {
+		allowed[event] = !self.reserved(event, false)
+	}
+
+	// lock both emitters in the order of their creation so concurrent opposite absorbs don't deadlock
+	first, second := self, other
+	if first.serial > second.serial {
+		first, second = second, first
+	}
+	first.mutex.Lock()
+	second.mutex.Lock()
+	if first.Frozen() || second.Frozen() {
+		second.mutex.Unlock()
+		first.mutex.Unlock()
+		self.emitMeta("schemaViolation", "", ErrFrozen)
+		return self
+	}
+
+	// in their registration order, the listeners of a registration on several patterns keeping a shared id
+	absorbed := []Listener{}
+	for event, listeners := range other.listeners {
+		if allowed[event.(string)] {
+			absorbed = append(absorbed, listeners...)
+			delete(other.listeners, event)
+		}
+	}
+	sort.Slice(absorbed, func(i, j int) bool { return absorbed[i].id < absorbed[j].id })
+
+	ids := map[uint64]uint64{}
+	added, removed := []Listener{}, []Listener{}
+	for _, l := range absorbed {
+		event := self.key(l.event)
+		existing := self.listeners[event]
+		conflict := -1
+		for i, e := range existing {
+			if e.pointer() == l.pointer() {
+				conflict = i
+				break
+			}
+		}
+
+		switch {
+		case conflict >= 0 && policy == KeepExisting:
+			continue
+		case conflict >= 0 && policy == KeepAbsorbed:
+			removed = append(removed, existing[conflict])
+			existing = append(existing[:conflict], existing[conflict+1:]...)
+		}
+
+		id, ok := ids[l.id]
+		if !ok {
+			self.sequence++
+			id = self.sequence
+			ids[l.id] = id
+		}
+		moved := l
+		moved.id, moved.event = id, event
+		self.compiled(event)
+		self.listeners[event] = append(existing, moved)
+		added = append(added, moved)
+	}
+	self.invalidate()
+	other.invalidate()
+
+	second.mutex.Unlock()
+	first.mutex.Unlock()
+
+	for _, l := range absorbed {
+		other.listenerRemoved(l.event, l)
+	}
+	for _, l := range removed {
+		self.listenerRemoved(l.event, l)
+	}
+	for _, l := range added {
+		self.listenerAdded(l.event, l)
+	}
+	return self
+}
+
+// emitters - the count of the emitters created, their serial numbers
+var emitters uint64
+
+// nextSerial() - the serial number of a new emitter
+func nextSerial() uint64 {
+	return atomic.AddUint64(&emitters, 1)
+}
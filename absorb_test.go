@@ -0,0 +1,50 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestAbsorb(t *testing.T) {
+	counter := 0
+	shared := func(args ...interface{}) { counter++ }
+
+	policies := map[ConflictPolicy]int{KeepBoth: 3, KeepExisting: 2, KeepAbsorbed: 2}
+	for policy, count := range policies {
+		emitter, other := Construct(), Construct()
+		emitter.On("testevent", shared)
+		other.On("testevent", shared)
+		other.On("otherevent", shared)
+
+		emitter.AbsorbWith(other, policy)
+		expect(t, 0, other.ListenersCount("testevent"))
+
+		counter = 0
+		emitter.EmitSync("testevent")
+		emitter.EmitSync("otherevent")
+		expect(t, count, counter)
+	}
+}
+
+func TestAbsorbPatternsAndChecks(t *testing.T) {
+	emitter, other := New(WithMatcher(AMQP)), New()
+	internal := emitter.Reserve("internal.*")
+	violations := 0
+	emitter.On("schemaViolation", func(args ...interface{}) { violations++ })
+
+	calls := 0
+	other.OnPatterns([]string{"user.#", "*.created.*"}, func(args ...interface{}) { calls++ })
+	other.On("internal.tick", func(args ...interface{}) { calls += 10 })
+	emitter.Absorb(other)
+
+	calls = 0
+	emitter.EmitSync("user.created.now")
+	expect(t, 1, calls, "the absorbed multi-pattern listener still runs once, matched in the emitter's dialect")
+	expect(t, 1, violations, "the reserved event is rejected")
+	expect(t, 1, other.ListenersCount("internal.tick"), "its listener stays")
+	internal.EmitSync("internal.tick")
+	expect(t, 1, calls)
+
+	emitter.Freeze()
+	emitter.Absorb(New())
+	expect(t, 2, violations, "a frozen emitter can't absorb")
+}
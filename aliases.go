@@ -0,0 +1,86 @@
+package Emitter
+
+// Alias() - make the emits of each name also dispatch to the listeners of the other one,
+// the old name is deprecated: emitting or listening on it runs the deprecation hook
+func (self *Emitter) Alias(old, new string) *Emitter {
+	if !self.lockUnfrozen(old) {
+		return self
+	}
+	defer self.mutex.Unlock()
+	old, new = self.key(old), self.key(new)
+
+	if self.aliases == nil {
+		self.aliases = make(map[string][]string)
+		self.deprecated = make(map[string]string)
+	}
+	self.aliases[old] = append(self.aliases[old], new)
+	self.aliases[new] = append(self.aliases[new], old)
+	self.deprecated[old] = new
+	return self
+}
+
+// Unalias() - stop dispatching the emits of the names to each other's listeners
+func (self *Emitter) Unalias(old, new string) *Emitter {
+	if !self.lockUnfrozen(old) {
+		return self
+	}
+	defer self.mutex.Unlock()
+	old, new = self.key(old), self.key(new)
+
+	self.aliases[old] = without(self.aliases[old], new)
+	self.aliases[new] = without(self.aliases[new], old)
+	if self.deprecated[old] == new {
+		delete(self.deprecated, old)
+	}
+	return self
+}
+
+// OnDeprecated() - set the hook run whenever a deprecated (aliased) event name is emitted or listened on
+func (self *Emitter) OnDeprecated(hook func(old, new string)) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.onDeprecated = hook
+	return self
+}
+
+// aliasNames() - the event and all the names aliased to it, transitively; the mutex must be held
+func (self *Emitter) aliasNames(event string) []string {
+	names := []string{event}
+	if len(self.aliases) == 0 {
+		return names
+	}
+
+	seen := map[string]bool{event: true}
+	for i := 0; i < len(names); i++ {
+		for _, alias := range self.aliases[names[i]] {
+			if !seen[alias] {
+				seen[alias] = true
+				names = append(names, alias)
+			}
+		}
+	}
+	return names
+}
+
+// deprecation() - run the deprecation hook if the event name is deprecated
+func (self *Emitter) deprecation(event string) {
+	self.mutex.Lock()
+	new, deprecated := self.deprecated[self.key(event)]
+	hook := self.onDeprecated
+	self.mutex.Unlock()
+
+	if deprecated && hook != nil {
+		hook(event, new)
+	}
+}
+
+func without(names []string, name string) []string {
+	result := []string{}
+	for _, n := range names {
+		if n != name {
+			result = append(result, n)
+		}
+	}
+	return result
+}
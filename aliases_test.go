@@ -0,0 +1,34 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestAlias(t *testing.T) {
+	emitter := Construct().Alias("user.signup", "user.created")
+
+	deprecations := 0
+	emitter.OnDeprecated(func(old, new string) {
+		expect(t, "user.signup", old)
+		expect(t, "user.created", new)
+		deprecations++
+	})
+
+	oldCount, newCount, allCount := 0, 0, 0
+	emitter.On("user.signup", func(args ...interface{}) { oldCount++ })
+	emitter.On("user.created", func(args ...interface{}) { newCount++ })
+	emitter.On("user.*", func(args ...interface{}) { allCount++ })
+
+	emitter.EmitSync("user.signup")
+	emitter.EmitSync("user.created")
+
+	expect(t, 2, oldCount)
+	expect(t, 2, newCount)
+	expect(t, 2, allCount, "a listener matching both names runs once")
+	expect(t, 2, deprecations, "deprecations on the listener and the emit")
+
+	emitter.Unalias("user.signup", "user.created")
+	emitter.EmitSync("user.created")
+	expect(t, 2, oldCount)
+	expect(t, 3, newCount)
+}
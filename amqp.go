@@ -0,0 +1,53 @@
+package Emitter
+
+import (
+	"strings"
+)
+
+// AMQP - the RabbitMQ topic exchange dialect: the names are words separated by ".", "*" matches
+// exactly one word and "#" zero or more words, "**" alone still matches every event
+var AMQP Matcher = amqpMatcher{}
+
+type amqpMatcher struct{}
+
+// Match() - report whether the routing key matches the binding pattern
+func (amqpMatcher) Match(pattern, event string) bool {
+	return amqpMatcher{}.Compile(pattern)(event)
+}
+
+// Compile() - split the pattern into its words once
+func (amqpMatcher) Compile(pattern string) func(event string) bool {
+	if pattern == "**" || pattern == "#" {
+		return func(string) bool { return true }
+	}
+	words := strings.Split(pattern, ".")
+	return func(event string) bool {
+		return matchWords(words, strings.Split(event, "."))
+	}
+}
+
+// matchWords() - the words matching with the backtracking of eventMatchPattern, "#" backtracks as a
+// star over the words and "*" stands for any single word
+func matchWords(pattern, words []string) bool {
+	p, w := 0, 0
+	hash, resume := -1, 0
+	for w < len(words) {
+		switch {
+		case p < len(pattern) && pattern[p] == "#":
+			hash, resume = p, w
+			p++
+		case p < len(pattern) && (pattern[p] == "*" || pattern[p] == words[w]):
+			p++
+			w++
+		case hash >= 0:
+			resume++
+			p, w = hash+1, resume
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == "#" {
+		p++
+	}
+	return p == len(pattern)
+}
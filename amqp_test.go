@@ -0,0 +1,47 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestAMQPMatch(t *testing.T) {
+	cases := []struct {
+		pattern, event string
+		matches        bool
+	}{
+		{"user.*", "user.created", true},
+		{"user.*", "user.profile.updated", false},
+		{"user.*", "user", false},
+		{"user.#", "user", true},
+		{"user.#", "user.profile.updated", true},
+		{"#.updated", "user.profile.updated", true},
+		{"#.updated", "updated", true},
+		{"*.*.updated", "user.profile.updated", true},
+		{"user.#.updated", "user.updated", true},
+		{"user.#.updated", "user.a.b.updated", true},
+		{"user.#.updated", "user.a.b.created", false},
+		{"#.a.#.b", "x.a.y.b", true},
+		{"#.a.#.b", "x.b.y.a", false},
+		{"user.created", "user.created", true},
+		{"user.cre*", "user.created", false},
+		{"#", "anything.at.all", true},
+		{"**", "anything.at.all", true},
+	}
+	for _, c := range cases {
+		if AMQP.Match(c.pattern, c.event) != c.matches {
+			t.Errorf("AMQP.Match(%q, %q) should be %v", c.pattern, c.event, c.matches)
+		}
+	}
+}
+
+func TestWithAMQPMatcher(t *testing.T) {
+	emitter := New(WithMatcher(AMQP))
+	one, any := 0, 0
+	emitter.On("order.*", func(args ...interface{}) { one++ })
+	emitter.On("order.#", func(args ...interface{}) { any++ })
+	emitter.EmitSync("order.created")
+	emitter.EmitSync("order.item.added")
+	emitter.EmitSync("order")
+	expect(t, 1, one)
+	expect(t, 3, any)
+}
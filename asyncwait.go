@@ -0,0 +1,73 @@
+package Emitter
+
+import (
+	"context"
+	"sync"
+)
+
+// EmitAsyncWait() - run all listeners of the event concurrently in the emitter's pool, like an errgroup
+// does, and wait for them: the first error of the listeners, see OnContextErr, or of their panics, as
+// a *PanicError, is returned and cancels the context the other listeners got from OnContext. The emit held
+// by the paused emitter is waited for until resumed, or until ctx is done
+func (self *Emitter) EmitAsyncWait(ctx context.Context, event string, args ...interface{}) error {
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var first error
+	once := &sync.Once{}
+	fail := func(err error) {
+		once.Do(func() {
+			first = err
+			cancel()
+		})
+	}
+
+	delivered := make(chan struct{})
+	ev := &Event{Name: event, Args: args, ctx: ctx, failed: fail}
+	err := self.dispatchWith(ev, true, func(ev *Event, async bool) int {
+		defer close(delivered)
+		if !self.admit(ev) {
+			return -1
+		}
+
+		n := 0
+		limits := self.limitsOf(ev.Name)
+		wg := &sync.WaitGroup{}
+		for _, v := range self.listenersOf(ev.Name) {
+			if v.once && !self.claimOnce(v) {
+				continue
+			}
+			v := v
+			n++
+			wg.Add(1)
+			self.submitLimited(func() {
+				defer wg.Done()
+				defer func() {
+					if r := recover(); r != nil {
+						fail(&PanicError{Listener: v.ID(), Event: ev.Name, Value: r})
+					}
+				}()
+				v.callWait(ev)
+			}, limits, ev.Priority)
+		}
+		wg.Wait()
+		return n
+	})
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-delivered:
+		return first
+	default:
+	}
+	// held by the paused emitter, until resumed or given up
+	select {
+	case <-delivered:
+		return first
+	case <-parent.Done():
+		return parent.Err()
+	}
+}
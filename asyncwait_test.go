@@ -0,0 +1,47 @@
+package Emitter
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestEmitAsyncWait(t *testing.T) {
+	pool := NewPool(4)
+	defer pool.Close()
+	emitter := New(WithPool(pool))
+
+	errInvalid := errors.New("invalid user")
+	started, cancelled := make(chan bool, 1), make(chan error, 1)
+	emitter.OnContextErr("user.created", func(ctx context.Context, args ...interface{}) error {
+		<-started
+		return errInvalid
+	})
+	emitter.OnContext("user.created", func(ctx context.Context, args ...interface{}) {
+		started <- true
+		select {
+		case <-ctx.Done():
+			cancelled <- ctx.Err()
+		case <-time.After(time.Second):
+			cancelled <- nil
+		}
+	})
+
+	expect(t, errInvalid, emitter.EmitAsyncWait(context.Background(), "user.created"))
+	expect(t, context.Canceled, <-cancelled, "the first error cancels the other listeners")
+
+	emitter.On("order.created", func(args ...interface{}) { panic("boom") })
+	err := emitter.EmitAsyncWait(context.Background(), "order.created")
+	expect(t, "emitter: listener 3 of order.created panicked: boom", err.Error())
+
+	emitter.OnContextErr("order.paid", func(ctx context.Context, args ...interface{}) error { return nil })
+	expect(t, nil, emitter.EmitAsyncWait(context.Background(), "order.paid"))
+
+	// the errors are listed by EmitAsyncResult
+	result := emitter.EmitAsyncResult("user.created").Wait()
+	expect(t, 1, len(result.Errors()))
+	expect(t, errInvalid, result.Errors()[0])
+	expect(t, 0, result.PanicCount())
+	expect(t, nil, <-cancelled, "the errors of the EmitAsyncResult don't cancel anything")
+}
@@ -0,0 +1,38 @@
+package Emitter
+
+// Op - an operation authorized by the emitter's auth callback
+type Op int
+
+const (
+	// OpEmit - emitting an event
+	OpEmit Op = iota
+	// OpListen - registering a listener on an event (pattern)
+	OpListen
+)
+
+func (self Op) String() string {
+	if self == OpListen {
+		return "listen"
+	}
+	return "emit"
+}
+
+// WithAuth() - consult the callback before each emit and registration: an error rejects it like the
+// reserved events are, reported by a "schemaViolation" meta-event with the error, so sandboxed
+// plugins can be restricted to their event namespaces. The emitter's own meta-events aren't checked
+func WithAuth(auth func(op Op, event string) error) Option {
+	return func(e *Emitter) {
+		e.auth = auth
+	}
+}
+
+// authorize() - the error of the auth callback, if any
+func (self *Emitter) authorize(event string, emit bool) error {
+	if self.auth == nil {
+		return nil
+	}
+	if emit {
+		return self.auth(OpEmit, event)
+	}
+	return self.auth(OpListen, event)
+}
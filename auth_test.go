@@ -0,0 +1,40 @@
+package Emitter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestWithAuth(t *testing.T) {
+	errForbidden := errors.New("forbidden")
+	emitter := New(WithAuth(func(op Op, event string) error {
+		if op == OpEmit && !strings.HasPrefix(event, "plugin.") {
+			return errForbidden
+		}
+		if op == OpListen && strings.HasPrefix(event, "admin.") {
+			return errForbidden
+		}
+		return nil
+	}))
+
+	violations := []interface{}{}
+	emitter.On("schemaViolation", func(args ...interface{}) { violations = append(violations, args[1]) })
+
+	calls := 0
+	emitter.On("plugin.ready", func(args ...interface{}) { calls++ })
+	emitter.On("admin.reset", func(args ...interface{}) { calls += 100 })
+	expect(t, 0, emitter.ListenersCount("admin.reset"), "the registration is rejected")
+
+	emitter.EmitSync("plugin.ready")
+	emitter.EmitSync("core.shutdown")
+	emitter.EmitAsync("core.shutdown", nil)
+	expect(t, 1, calls)
+	expect(t, 3, len(violations))
+	expect(t, errForbidden, violations[0])
+
+	expect(t, errForbidden, emitter.CheckEmit("core.shutdown"))
+	expect(t, nil, emitter.CheckEmit("plugin.ready"))
+	expect(t, errForbidden, emitter.CheckListen("admin.reset"))
+	expect(t, "listen", OpListen.String())
+}
@@ -0,0 +1,73 @@
+package Emitter
+
+// balanceKey - the round robin counter of a group of listeners of an event
+type balanceKey struct {
+	event string
+	group string
+}
+
+// Group() - put the subscribed listener in the group, EmitBalanced runs one listener per group,
+// as moleculer balances an event between the instances of a service
+func (self *Subscription) Group(group string) *Subscription {
+	return self.update(func(l *Listener) { l.group = group })
+}
+
+// Group() - the group of the listener, "" if none
+func (self Listener) Group() string {
+	return self.group
+}
+
+// Broadcast() - run all the listeners of the event in synchronous mode, as EmitSync does
+func (self *Emitter) Broadcast(event string, args ...interface{}) *Emitter {
+	return self.EmitSync(event, args...)
+}
+
+// EmitBalanced() - run one listener per group of the event in synchronous mode, picked in turn
+// from the group's listeners on each emit; the listeners without group are each their own group
+func (self *Emitter) EmitBalanced(event string, args ...interface{}) *Emitter {
+	self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		return self.run(ev, self.balance(ev.Name, self.listenersOf(ev.Name)), false)
+	})
+	return self
+}
+
+// balance() - the next listener of each group, in the registration order of the groups' first listener
+func (self *Emitter) balance(event string, listeners []Listener) []Listener {
+	groups := map[string][]Listener{}
+	order := []string{}
+	balanced := []Listener{}
+	for _, l := range listeners {
+		if l.group == "" {
+			balanced = append(balanced, l)
+			continue
+		}
+		if _, ok := groups[l.group]; !ok {
+			order = append(order, l.group)
+			// keep the group's place among the ungrouped listeners
+			balanced = append(balanced, Listener{})
+		}
+		groups[l.group] = append(groups[l.group], l)
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.balancing == nil {
+		self.balancing = make(map[balanceKey]uint64)
+	}
+	next := 0
+	for i, l := range balanced {
+		if l.id != 0 {
+			continue
+		}
+		group := groups[order[next]]
+		key := balanceKey{event: self.key(event), group: order[next]}
+		balanced[i] = group[self.balancing[key]%uint64(len(group))]
+		self.balancing[key]++
+		next++
+	}
+	return balanced
+}
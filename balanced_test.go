@@ -0,0 +1,27 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitBalanced(t *testing.T) {
+	emitter := Construct()
+
+	calls := []string{}
+	listener := func(name string) func(...interface{}) {
+		return func(args ...interface{}) { calls = append(calls, name) }
+	}
+	emitter.OnEvents([]string{"user.created"}, listener("mail-1"))[0].Group("mail")
+	emitter.OnEvents([]string{"user.*"}, listener("audit"))
+	emitter.OnEvents([]string{"user.created"}, listener("mail-2"))[0].Group("mail")
+
+	emitter.EmitBalanced("user.created")
+	emitter.EmitBalanced("user.created")
+	emitter.EmitBalanced("user.created")
+	expect(t, "mail-1,audit,mail-2,audit,mail-1,audit", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	emitter.Broadcast("user.created")
+	expect(t, "mail-1,audit,mail-2", strings.Join(calls, ","))
+}
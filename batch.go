@@ -0,0 +1,69 @@
+package Emitter
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// batcher - the args of the emits buffered for a batch listener
+type batcher struct {
+	window   time.Duration
+	size     int
+	clock    Clock
+	callback func(batch [][]interface{})
+	pending  [][]interface{}
+	timer    Timer
+	mutex    *sync.Mutex
+}
+
+// OnBatch() - register a new listener receiving the args of the emits of the event by batches: the
+// emits are buffered until size of them are (the last one delivering the batch), or the window since
+// the first one elapsed (the batch being delivered in its own goroutine), to reduce the per-event
+// overhead of the batch-friendly listeners, i.e the bulk DB writers. A size <= 0 or a window <= 0
+// doesn't bound the batches by it; a batch pending when the listener is removed is still delivered
+func (self *Emitter) OnBatch(event string, window time.Duration, size int, callback func(batch [][]interface{})) *Subscription {
+	if window <= 0 && size <= 0 {
+		size = 1
+	}
+	b := &batcher{window: window, size: size, clock: self.Clock(), callback: callback, mutex: &sync.Mutex{}}
+	return self.addListenerInternal(event, Listener{callback: b.add, origin: reflect.ValueOf(callback).Pointer()})
+}
+
+// add() - buffer the args of the emit, delivering the batch once full
+func (self *batcher) add(args ...interface{}) {
+	self.mutex.Lock()
+	self.pending = append(self.pending, args)
+	if self.size > 0 && len(self.pending) >= self.size {
+		batch := self.take()
+		self.mutex.Unlock()
+		self.callback(batch)
+		return
+	}
+	if len(self.pending) == 1 && self.window > 0 {
+		self.timer = self.clock.AfterFunc(self.window, self.flush)
+	}
+	self.mutex.Unlock()
+}
+
+// flush() - deliver the pending batch, once its window elapsed
+func (self *batcher) flush() {
+	self.mutex.Lock()
+	batch := self.take()
+	self.mutex.Unlock()
+
+	if len(batch) > 0 {
+		self.callback(batch)
+	}
+}
+
+// take() - the pending batch, emptied, the mutex must be held
+func (self *batcher) take() [][]interface{} {
+	if self.timer != nil {
+		self.timer.Stop()
+		self.timer = nil
+	}
+	batch := self.pending
+	self.pending = nil
+	return batch
+}
@@ -0,0 +1,44 @@
+package Emitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnBatch(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	emitter := New(WithClock(clock))
+	batches := make(chan [][]interface{}, 10)
+	emitter.OnBatch("row.inserted", 10*time.Millisecond, 3, func(batch [][]interface{}) { batches <- batch })
+
+	for i := 1; i <= 4; i++ {
+		emitter.EmitSync("row.inserted", i)
+	}
+	batch := <-batches
+	expect(t, 3, len(batch), "the full batch is delivered")
+	expect(t, 1, batch[0][0])
+	expect(t, 3, batch[2][0])
+	expect(t, 0, len(batches))
+
+	clock.Advance(10 * time.Millisecond)
+	batch = <-batches
+	expect(t, 1, len(batch), "the batch is delivered once its window elapsed")
+	expect(t, 4, batch[0][0])
+
+	clock.Advance(time.Second)
+	expect(t, 0, len(batches), "no empty batch")
+}
+
+func TestOnBatchUnbounded(t *testing.T) {
+	emitter := New()
+	batches := [][][]interface{}{}
+	emitter.OnBatch("row.inserted", 0, 2, func(batch [][]interface{}) { batches = append(batches, batch) })
+	emitter.OnBatch("row.deleted", 0, 0, func(batch [][]interface{}) { batches = append(batches, batch) })
+
+	emitter.EmitSync("row.inserted", 1, "a")
+	emitter.EmitSync("row.inserted", 2, "b")
+	emitter.EmitSync("row.deleted", 3)
+	expect(t, 2, len(batches))
+	expect(t, "b", batches[0][1][1])
+	expect(t, 1, len(batches[1]), "a batch listener without bounds gets each emit")
+}
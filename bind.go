@@ -0,0 +1,299 @@
+package Emitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Validator - a bound payload checking itself once its fields are set, see OnBind
+type Validator interface {
+	Validate() error
+}
+
+// OnBind() - register fn, a func(T) with T a struct or a pointer to a struct, on the event: the first
+// arg of the emits is bound into a new T, a T itself, a map[string]interface{} or a JSON document
+// ([]byte, json.RawMessage or string), converting the values to the field types.
+// The fields are named by their `bind:"name"` tag, else their `json:"name"` tag, else their name,
+// and `bind:"name,required"` rejects the payloads missing them. A T implementing Validator is checked
+// once bound. The payloads failing to bind raise a "schemaViolation" meta-event and fn isn't called.
+func (self *Emitter) OnBind(event string, fn interface{}) *Subscription {
+	value := reflect.ValueOf(fn)
+	typ := value.Type()
+	if typ.Kind() != reflect.Func || typ.NumIn() != 1 || !isStruct(typ.In(0)) {
+		panic(fmt.Sprintf("emitter: OnBind expects a func(T) with T a struct or a pointer to a struct, got %T", fn))
+	}
+
+	target := typ.In(0)
+	handler := func(ev *Event) {
+		var payload interface{}
+		if len(ev.Args) > 0 {
+			payload = ev.Args[0]
+		}
+		bound := reflect.New(target)
+		if err := Bind(payload, bound.Interface()); err != nil {
+			self.emitMeta("schemaViolation", ev.Name, err)
+			return
+		}
+		value.Call([]reflect.Value{bound.Elem()})
+	}
+	return self.addListenerInternal(event, Listener{handler: handler, origin: value.Pointer()})
+}
+
+// Bind() - bind the payload into out, a pointer to a struct or to a pointer to a struct,
+// following the rules of OnBind
+func Bind(payload interface{}, out interface{}) error {
+	ptr := reflect.ValueOf(out)
+	if ptr.Kind() != reflect.Ptr || ptr.IsNil() || !isStruct(ptr.Elem().Type()) {
+		return fmt.Errorf("emitter: can't bind into %T", out)
+	}
+	target := ptr.Elem()
+	if target.Kind() == reflect.Ptr {
+		target.Set(reflect.New(target.Type().Elem()))
+		target = target.Elem()
+	}
+
+	if err := bindPayload(target, payload); err != nil {
+		return err
+	}
+	if validator, ok := target.Addr().Interface().(Validator); ok {
+		return validator.Validate()
+	}
+	return nil
+}
+
+// bindPayload() - set the struct from the payload, whatever its form
+func bindPayload(target reflect.Value, payload interface{}) error {
+	switch p := payload.(type) {
+	case nil:
+		return bindMap(target, nil)
+	case map[string]interface{}:
+		return bindMap(target, p)
+	case []byte:
+		return bindJSON(target, p)
+	case json.RawMessage:
+		return bindJSON(target, p)
+	case string:
+		return bindJSON(target, []byte(p))
+	}
+
+	value := reflect.ValueOf(payload)
+	if value.Type().AssignableTo(target.Type()) {
+		target.Set(value)
+		return bindMap(target, nil, true)
+	}
+	if value.Kind() == reflect.Ptr && !value.IsNil() && value.Elem().Type().AssignableTo(target.Type()) {
+		target.Set(value.Elem())
+		return bindMap(target, nil, true)
+	}
+
+	// any other shape goes through its JSON document
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("emitter: can't bind %T: %v", payload, err)
+	}
+	return bindJSON(target, data)
+}
+
+// bindJSON() - set the struct from the JSON object
+func bindJSON(target reflect.Value, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	fields := map[string]interface{}{}
+	if err := decoder.Decode(&fields); err != nil {
+		return fmt.Errorf("emitter: can't bind the payload: %v", err)
+	}
+	return bindMap(target, fields)
+}
+
+// bindMap() - set the fields of the struct from the values, checking the required ones are there;
+// only the required fields are checked, against their zero value, when the struct is already set
+func bindMap(target reflect.Value, values map[string]interface{}, set ...bool) error {
+	typ := target.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		name, required := bindTag(field)
+		if name == "-" {
+			continue
+		}
+
+		if len(set) > 0 {
+			if required && isZero(target.Field(i)) {
+				return fmt.Errorf("emitter: field %s required", name)
+			}
+			continue
+		}
+
+		value, ok := values[name]
+		if !ok {
+			value, ok = lookupFold(values, name)
+		}
+		if !ok || value == nil {
+			if required {
+				return fmt.Errorf("emitter: field %s required", name)
+			}
+			continue
+		}
+		if err := setValue(target.Field(i), value); err != nil {
+			return fmt.Errorf("emitter: field %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// bindTag() - the name of the field in the payloads and whether it's required
+func bindTag(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("bind")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = field.Name
+	}
+	required := false
+	for _, option := range parts[1:] {
+		if option == "required" {
+			required = true
+		}
+	}
+	return name, required
+}
+
+// lookupFold() - the value of the key equal to the name under case folding, like encoding/json does
+func lookupFold(values map[string]interface{}, name string) (interface{}, bool) {
+	for key, value := range values {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// setValue() - set the field to the value, converted to the field's type
+func setValue(field reflect.Value, value interface{}) error {
+	v := reflect.ValueOf(value)
+	typ := field.Type()
+	if v.Type().AssignableTo(typ) {
+		field.Set(v)
+		return nil
+	}
+
+	switch typ.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(typ.Elem())
+		if err := setValue(elem.Elem(), value); err != nil {
+			return err
+		}
+		field.Set(elem)
+		return nil
+	case reflect.Struct:
+		if values, ok := value.(map[string]interface{}); ok {
+			return bindMap(field, values)
+		}
+	case reflect.Slice:
+		if items, ok := value.([]interface{}); ok {
+			slice := reflect.MakeSlice(typ, len(items), len(items))
+			for i, item := range items {
+				if item == nil {
+					continue
+				}
+				if err := setValue(slice.Index(i), item); err != nil {
+					return err
+				}
+			}
+			field.Set(slice)
+			return nil
+		}
+	case reflect.String:
+		if v.Kind() == reflect.String {
+			field.SetString(v.String())
+			return nil
+		}
+	case reflect.Bool:
+		switch v.Kind() {
+		case reflect.Bool:
+			field.SetBool(v.Bool())
+			return nil
+		case reflect.String:
+			b, err := strconv.ParseBool(v.String())
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toFloat(v)
+		if err != nil {
+			return err
+		}
+		if n != float64(int64(n)) || field.OverflowInt(int64(n)) {
+			return fmt.Errorf("%v doesn't fit %v", value, typ)
+		}
+		field.SetInt(int64(n))
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toFloat(v)
+		if err != nil {
+			return err
+		}
+		if n < 0 || n != float64(uint64(n)) || field.OverflowUint(uint64(n)) {
+			return fmt.Errorf("%v doesn't fit %v", value, typ)
+		}
+		field.SetUint(uint64(n))
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := toFloat(v)
+		if err != nil {
+			return err
+		}
+		field.SetFloat(n)
+		return nil
+	}
+
+	// the types decoding themselves, i.e time.Time, go through JSON
+	data, err := json.Marshal(value)
+	if err == nil {
+		err = json.Unmarshal(data, field.Addr().Interface())
+	}
+	if err != nil {
+		return fmt.Errorf("can't convert %T to %v", value, typ)
+	}
+	return nil
+}
+
+// toFloat() - the number held by the value, a number, a json.Number or a numeric string
+func toFloat(v reflect.Value) (float64, error) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), nil
+	case reflect.String:
+		return strconv.ParseFloat(v.String(), 64)
+	}
+	return 0, fmt.Errorf("can't convert %v to a number", v.Type())
+}
+
+// isStruct() - whether the type is a struct or a pointer to a struct
+func isStruct(typ reflect.Type) bool {
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	return typ.Kind() == reflect.Struct
+}
+
+// isZero() - whether the value is its type's zero value
+func isZero(v reflect.Value) bool {
+	return reflect.DeepEqual(v.Interface(), reflect.Zero(v.Type()).Interface())
+}
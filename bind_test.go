@@ -0,0 +1,84 @@
+package Emitter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type boundUser struct {
+	ID      int       `bind:"id,required"`
+	Name    string    `json:"name"`
+	Admin   bool      `bind:"admin"`
+	Tags    []string  `bind:"tags"`
+	Created time.Time `bind:"created"`
+	Score   *float64
+}
+
+func (self boundUser) Validate() error {
+	if self.ID < 0 {
+		return errors.New("negative id")
+	}
+	return nil
+}
+
+func TestOnBind(t *testing.T) {
+	emitter := New()
+	bound := []boundUser{}
+	violations := []error{}
+	emitter.OnBind("user.created", func(p boundUser) { bound = append(bound, p) })
+	emitter.On("schemaViolation", func(args ...interface{}) { violations = append(violations, args[1].(error)) })
+
+	emitter.EmitSync("user.created", map[string]interface{}{
+		"id": 1.0, "NAME": "ann", "admin": "true", "tags": []interface{}{"a", "b"},
+		"created": "2020-01-02T03:04:05Z", "score": 2.5,
+	})
+	emitter.EmitSync("user.created", []byte(`{"id": 2, "name": "bob"}`))
+	emitter.EmitSync("user.created", `{"id": "3"}`)
+	emitter.EmitSync("user.created", boundUser{ID: 4})
+	emitter.EmitSync("user.created", &boundUser{ID: 5})
+	emitter.EmitSync("user.created", struct {
+		ID int `json:"id"`
+	}{6})
+
+	expect(t, 6, len(bound))
+	expect(t, 0, len(violations))
+	expect(t, "ann", bound[0].Name, "the field names fold their case")
+	expect(t, true, bound[0].Admin)
+	expect(t, "a,b", strings.Join(bound[0].Tags, ","))
+	expect(t, 2020, bound[0].Created.Year())
+	expect(t, 2.5, *bound[0].Score)
+	expect(t, "bob", bound[1].Name)
+	for i, p := range bound {
+		expect(t, i+1, p.ID)
+	}
+
+	emitter.EmitSync("user.created", map[string]interface{}{"name": "eve"})
+	emitter.EmitSync("user.created", map[string]interface{}{"id": 1.5})
+	emitter.EmitSync("user.created", map[string]interface{}{"id": -1})
+	emitter.EmitSync("user.created", []byte(`{"id":`))
+	expect(t, 6, len(bound), "the invalid payloads aren't delivered")
+	expect(t, 4, len(violations))
+	expect(t, "emitter: field id required", violations[0].Error())
+	expect(t, "negative id", violations[2].Error())
+}
+
+func TestOnBindPointer(t *testing.T) {
+	emitter := New()
+	var got *boundUser
+	emitter.OnBind("user.created", func(p *boundUser) { got = p })
+	emitter.EmitSync("user.created", map[string]interface{}{"id": 7})
+	expect(t, 7, got.ID)
+
+	defer func() { expect(t, true, recover() != nil, "the func must take a struct") }()
+	emitter.OnBind("user.created", func(id int) {})
+}
+
+func TestBind(t *testing.T) {
+	var p boundUser
+	expect(t, nil, Bind(map[string]interface{}{"id": uint8(9)}, &p))
+	expect(t, 9, p.ID)
+	expect(t, true, Bind(map[string]interface{}{"id": 300.0, "tags": 1}, &p) != nil)
+	expect(t, true, Bind(map[string]interface{}{}, p) != nil, "the target must be a pointer")
+}
@@ -0,0 +1,164 @@
+package Emitter
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// HeaderBusPath - the header holding the comma separated names of the bus emitters an event went through
+const HeaderBusPath = "bus.path"
+
+var (
+	// ErrEmitterExists - an emitter is already registered with the name
+	ErrEmitterExists = errors.New("emitter: an emitter is already registered with this name")
+	// ErrUnknownEmitter - no emitter is registered with the name
+	ErrUnknownEmitter = errors.New("emitter: unknown emitter")
+)
+
+// Bus - a registry of named emitters routing events between them
+type Bus struct {
+	emitters map[string]*Emitter
+	routes   []*Route
+	mutex    *sync.Mutex
+}
+
+// Route - forwards the events matching the pattern from the source emitter to the destination one
+type Route struct {
+	Source      string
+	Pattern     string
+	Destination string
+
+	bus          *Bus
+	subscription *Subscription
+}
+
+// NewBus() - create a new empty bus
+func NewBus() *Bus {
+	return &Bus{
+		emitters: make(map[string]*Emitter),
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// Register() - register the emitter with the specified name
+func (self *Bus) Register(name string, e *Emitter) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if _, ok := self.emitters[name]; ok {
+		return ErrEmitterExists
+	}
+	self.emitters[name] = e
+	return nil
+}
+
+// Unregister() - unregister the named emitter and remove the routes from/to it
+func (self *Bus) Unregister(name string) {
+	self.mutex.Lock()
+	delete(self.emitters, name)
+	removed := []*Route{}
+	routes := self.routes[:0]
+	for _, route := range self.routes {
+		if route.Source == name || route.Destination == name {
+			removed = append(removed, route)
+		} else {
+			routes = append(routes, route)
+		}
+	}
+	self.routes = routes
+	self.mutex.Unlock()
+
+	for _, route := range removed {
+		route.subscription.Remove()
+	}
+}
+
+// Emitter() - return the named emitter, nil if none
+func (self *Bus) Emitter(name string) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return self.emitters[name]
+}
+
+// Names() - return the names of the registered emitters
+func (self *Bus) Names() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	names := make([]string, 0, len(self.emitters))
+	for name := range self.emitters {
+		names = append(names, name)
+	}
+	return names
+}
+
+// Route() - forward the events matching the pattern from the source emitter to the destination one,
+// an event never goes through the same emitter twice so cyclic routes are safe
+func (self *Bus) Route(source, pattern, destination string) (*Route, error) {
+	self.mutex.Lock()
+	src, dst := self.emitters[source], self.emitters[destination]
+	self.mutex.Unlock()
+
+	if src == nil || dst == nil {
+		return nil, ErrUnknownEmitter
+	}
+
+	route := &Route{Source: source, Pattern: pattern, Destination: destination, bus: self}
+	route.subscription = src.OnEvent(pattern, func(ev *Event) {
+		route.forward(ev, dst)
+	})
+
+	self.mutex.Lock()
+	self.routes = append(self.routes, route)
+	self.mutex.Unlock()
+	return route, nil
+}
+
+// Routes() - return the registered routes
+func (self *Bus) Routes() []*Route {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return append([]*Route{}, self.routes...)
+}
+
+// Remove() - stop forwarding the events of the route
+func (self *Route) Remove() {
+	self.subscription.Remove()
+
+	self.bus.mutex.Lock()
+	defer self.bus.mutex.Unlock()
+
+	for k, route := range self.bus.routes {
+		if route == self {
+			self.bus.routes = append(self.bus.routes[:k], self.bus.routes[k+1:]...)
+			break
+		}
+	}
+}
+
+// forward() - emit the event on the destination, unless it already went through it
+func (self *Route) forward(ev *Event, dst *Emitter) {
+	if IsMetaEvent(ev.Name) {
+		return
+	}
+
+	path := ev.Headers[HeaderBusPath]
+	if path == "" {
+		path = self.Source
+	}
+	for _, name := range strings.Split(path, ",") {
+		if name == self.Destination {
+			return
+		}
+	}
+
+	headers := map[string]string{}
+	for k, v := range ev.Headers {
+		headers[k] = v
+	}
+	headers[HeaderBusPath] = path + "," + self.Destination
+	dst.EmitEvent(&Event{Name: ev.Name, Args: ev.Args, Headers: headers})
+}
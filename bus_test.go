@@ -0,0 +1,43 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestBusRoutes(t *testing.T) {
+	bus := NewBus()
+	users, audit := Construct(), Construct()
+	bus.Register("users", users)
+	bus.Register("audit", audit)
+
+	expect(t, ErrEmitterExists, bus.Register("users", Construct()))
+	if _, err := bus.Route("users", "**", "unknown"); err != ErrUnknownEmitter {
+		t.Errorf("expected ErrUnknownEmitter, got %v", err)
+	}
+
+	// a cycle, every event reaches each emitter once
+	route, _ := bus.Route("users", "user.*", "audit")
+	bus.Route("audit", "**", "users")
+
+	usersCount, auditCount := 0, 0
+	users.On("user.created", func(args ...interface{}) { usersCount++ })
+	audit.On("user.created", func(args ...interface{}) { auditCount++ })
+
+	users.EmitSync("user.created", "john")
+	expect(t, 1, usersCount)
+	expect(t, 1, auditCount)
+
+	audit.EmitSync("user.created", "john")
+	expect(t, 2, usersCount)
+	expect(t, 2, auditCount)
+
+	route.Remove()
+	users.EmitSync("user.created", "john")
+	expect(t, 3, usersCount)
+	expect(t, 2, auditCount)
+	expect(t, 1, len(bus.Routes()))
+
+	bus.Unregister("audit")
+	expect(t, 0, len(bus.Routes()))
+	expect(t, 1, users.ListenersCount("user.created"))
+}
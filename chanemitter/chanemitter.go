@@ -0,0 +1,306 @@
+// Package chanemitter adapts an emitter to the channel API of olebedev/emitter,
+// so the codebases consuming events from channels can migrate without
+// rewriting their call sites:
+//
+//	e := chanemitter.New(goemitter.Construct())
+//	go func() {
+//		<-e.Emit("change", 42) // wait for the delivery
+//	}()
+//	for ev := range e.On("*") {
+//		fmt.Println(ev.Topic, ev.Int(0))
+//	}
+//
+// The topics are emitter patterns. As in olebedev/emitter, the events are sent
+// to the channels one after the other in a goroutine of their own, a channel
+// not ready to receive blocks the delivery, unless the event has FlagSkip.
+package chanemitter
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	goemitter "github.com/moleculer-go/goemitter"
+)
+
+// Flag - the delivery options of an event, set by the middlewares
+type Flag int
+
+const (
+	// FlagOnce - remove the listener once the event is sent, and close its channel
+	FlagOnce Flag = 1 << iota
+	// FlagVoid - don't send the event to the listener
+	FlagVoid
+	// FlagSkip - drop the event if the listener's channel isn't ready to receive it
+	FlagSkip
+	// FlagClose - remove the listener and close its channel instead of sending the event
+	FlagClose
+)
+
+// Event - an emitted event as received from the channels
+type Event struct {
+	// Topic - the emitted event name
+	Topic string
+	// OriginalTopic - the pattern of the listener receiving the event
+	OriginalTopic string
+	Flags         Flag
+	Args          []interface{}
+}
+
+// Int() - the arg at the index as an int, 0 if it isn't one
+func (self Event) Int(index int) int {
+	v, _ := self.arg(index).(int)
+	return v
+}
+
+// String() - the arg at the index as a string, formatted if it isn't one
+func (self Event) String(index int) string {
+	v := self.arg(index)
+	if s, ok := v.(string); ok {
+		return s
+	}
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// Float() - the arg at the index as a float64, 0 if it isn't one
+func (self Event) Float(index int) float64 {
+	v, _ := self.arg(index).(float64)
+	return v
+}
+
+// Bool() - the arg at the index as a bool, false if it isn't one
+func (self Event) Bool(index int) bool {
+	v, _ := self.arg(index).(bool)
+	return v
+}
+
+func (self Event) arg(index int) interface{} {
+	if index < 0 || index >= len(self.Args) {
+		return nil
+	}
+	return self.Args[index]
+}
+
+// Emitter - the channel API on top of an emitter
+type Emitter struct {
+	// Cap - the buffer size of the channels returned by On and Once
+	Cap uint
+
+	emitter     *goemitter.Emitter
+	listeners   map[string][]*listener
+	middlewares map[string][]func(*Event)
+	mutex       *sync.Mutex
+}
+
+// listener - a channel receiving the events of a topic
+type listener struct {
+	topic        string
+	ch           chan Event
+	flags        Flag
+	middlewares  []func(*Event)
+	subscription *goemitter.Subscription
+	// done - closed once the listener is removed, to unblock its senders
+	done   chan struct{}
+	closed bool
+	mutex  *sync.RWMutex
+}
+
+// New() - create the channel API of the emitter
+func New(e *goemitter.Emitter) *Emitter {
+	return &Emitter{
+		emitter:     e,
+		listeners:   make(map[string][]*listener),
+		middlewares: make(map[string][]func(*Event)),
+		mutex:       &sync.Mutex{},
+	}
+}
+
+// Source() - the emitter the events go through
+func (self *Emitter) Source() *goemitter.Emitter {
+	return self.emitter
+}
+
+// Use() - run the middlewares on the events of the topics matching the pattern, before the listeners' own
+func (self *Emitter) Use(pattern string, middlewares ...func(*Event)) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.middlewares[pattern] = append(self.middlewares[pattern], middlewares...)
+}
+
+// On() - a channel receiving the events of the topic, the middlewares run on each of them before it's sent
+func (self *Emitter) On(topic string, middlewares ...func(*Event)) <-chan Event {
+	return self.on(topic, 0, middlewares)
+}
+
+// Once() - a channel receiving the next event of the topic only, it's closed then
+func (self *Emitter) Once(topic string, middlewares ...func(*Event)) <-chan Event {
+	return self.on(topic, FlagOnce, middlewares)
+}
+
+// Off() - remove the channels listening on the topic, all of them if none is given, and close them
+func (self *Emitter) Off(topic string, channels ...<-chan Event) {
+	self.mutex.Lock()
+	removed := []*listener{}
+	for _, l := range self.listeners[topic] {
+		if len(channels) == 0 || contains(channels, l.ch) {
+			removed = append(removed, l)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, l := range removed {
+		self.off(l)
+	}
+}
+
+// Emit() - send the event to the channels listening on its topic, the returned channel is closed
+// once it's delivered to all of them
+func (self *Emitter) Emit(topic string, args ...interface{}) chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		self.emitter.EmitEvent(&goemitter.Event{Name: topic, Args: args})
+	}()
+	return done
+}
+
+// Listeners() - the channels listening on the topic
+func (self *Emitter) Listeners(topic string) []<-chan Event {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	channels := make([]<-chan Event, len(self.listeners[topic]))
+	for i, l := range self.listeners[topic] {
+		channels[i] = l.ch
+	}
+	return channels
+}
+
+// Topics() - the topics listened on, sorted
+func (self *Emitter) Topics() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	topics := make([]string, 0, len(self.listeners))
+	for topic := range self.listeners {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+	return topics
+}
+
+// on() - register a new channel listening on the topic
+func (self *Emitter) on(topic string, flags Flag, middlewares []func(*Event)) <-chan Event {
+	l := &listener{
+		topic:       topic,
+		ch:          make(chan Event, self.Cap),
+		flags:       flags,
+		middlewares: middlewares,
+		done:        make(chan struct{}),
+		mutex:       &sync.RWMutex{},
+	}
+
+	self.mutex.Lock()
+	self.listeners[topic] = append(self.listeners[topic], l)
+	self.mutex.Unlock()
+
+	l.subscription = self.emitter.OnEvent(topic, func(ev *goemitter.Event) {
+		self.send(l, ev)
+	})
+	return l.ch
+}
+
+// off() - remove the listener and close its channel, once its pending sends are unblocked
+func (self *Emitter) off(l *listener) {
+	self.mutex.Lock()
+	listeners := self.listeners[l.topic]
+	for i, v := range listeners {
+		if v == l {
+			self.listeners[l.topic] = append(listeners[:i:i], listeners[i+1:]...)
+			break
+		}
+	}
+	if len(self.listeners[l.topic]) == 0 {
+		delete(self.listeners, l.topic)
+	}
+	self.mutex.Unlock()
+
+	l.subscription.Remove()
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if !l.closed {
+		l.closed = true
+		close(l.done)
+		close(l.ch)
+	}
+}
+
+// send() - run the middlewares on the event and send it to the listener's channel
+func (self *Emitter) send(l *listener, emitted *goemitter.Event) {
+	if goemitter.IsMetaEvent(emitted.Name) {
+		return
+	}
+
+	ev := Event{Topic: emitted.Name, OriginalTopic: l.topic, Flags: l.flags, Args: emitted.Args}
+	self.use(&ev, self.globalMiddlewares(emitted.Name))
+	self.use(&ev, l.middlewares)
+
+	if ev.Flags&FlagClose != 0 {
+		self.off(l)
+		return
+	}
+	if ev.Flags&FlagVoid == 0 {
+		l.mutex.RLock()
+		if !l.closed {
+			if ev.Flags&FlagSkip != 0 {
+				select {
+				case l.ch <- ev:
+				default:
+				}
+			} else {
+				select {
+				case l.ch <- ev:
+				case <-l.done:
+				}
+			}
+		}
+		l.mutex.RUnlock()
+	}
+	if ev.Flags&FlagOnce != 0 {
+		self.off(l)
+	}
+}
+
+// globalMiddlewares() - the middlewares of the patterns matching the topic
+func (self *Emitter) globalMiddlewares(topic string) []func(*Event) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	middlewares := []func(*Event){}
+	for pattern, mws := range self.middlewares {
+		if goemitter.Match(pattern, topic) {
+			middlewares = append(middlewares, mws...)
+		}
+	}
+	return middlewares
+}
+
+func (self *Emitter) use(ev *Event, middlewares []func(*Event)) {
+	for _, middleware := range middlewares {
+		middleware(ev)
+	}
+}
+
+func contains(channels []<-chan Event, ch chan Event) bool {
+	for _, c := range channels {
+		if c == (<-chan Event)(ch) {
+			return true
+		}
+	}
+	return false
+}
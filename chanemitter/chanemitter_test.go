@@ -0,0 +1,78 @@
+package chanemitter
+
+import (
+	"testing"
+
+	goemitter "github.com/moleculer-go/goemitter"
+)
+
+func TestOnEmit(t *testing.T) {
+	source := goemitter.Construct()
+	e := New(source)
+	e.Cap = 2
+
+	events := e.On("user.*")
+	<-e.Emit("user.created", "john", 42)
+	source.EmitSync("user.deleted", "jane")
+
+	ev := <-events
+	expect(t, "user.created", ev.Topic)
+	expect(t, "user.*", ev.OriginalTopic)
+	expect(t, "john", ev.String(0))
+	expect(t, 42, ev.Int(1))
+	expect(t, "jane", (<-events).String(0), "the events emitted on the emitter are received too")
+
+	expect(t, 1, len(e.Listeners("user.*")))
+	e.Off("user.*")
+	_, open := <-events
+	expect(t, false, open)
+	expect(t, 0, len(e.Topics()))
+}
+
+func TestOnce(t *testing.T) {
+	e := New(goemitter.Construct())
+
+	once := e.Once("ping")
+	go e.Emit("ping", true)
+
+	count := 0
+	for ev := range once {
+		expect(t, true, ev.Bool(0))
+		count++
+	}
+	expect(t, 1, count)
+}
+
+func TestMiddlewares(t *testing.T) {
+	e := New(goemitter.Construct())
+	e.Use("*", func(ev *Event) {
+		if ev.Topic == "debug" {
+			ev.Flags |= FlagVoid
+		}
+	})
+
+	skipping := e.On("**", func(ev *Event) { ev.Flags |= FlagSkip })
+	events := e.On("**")
+	go func() {
+		<-e.Emit("debug")
+		<-e.Emit("info", 1.5)
+	}()
+
+	expect(t, "info", (<-events).Topic)
+	expect(t, 0, len(skipping), "nobody was receiving, the events were skipped")
+}
+
+func TestOffUnblocksSenders(t *testing.T) {
+	e := New(goemitter.Construct())
+
+	events := e.On("tick")
+	done := e.Emit("tick")
+	e.Off("tick", events)
+	<-done
+}
+
+func expect(t *testing.T, a interface{}, b interface{}, desc ...string) {
+	if a != b {
+		t.Errorf("%v Expected %v - Got %v", desc, a, b)
+	}
+}
@@ -0,0 +1,62 @@
+package Emitter
+
+import (
+	"reflect"
+)
+
+// SetComparator() - compare the values of the event's EmitChanged with equal instead of reflect.DeepEqual
+func (self *Emitter) SetComparator(event string, equal func(a, b interface{}) bool) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.comparators == nil {
+		self.comparators = make(map[string]func(a, b interface{}) bool)
+	}
+	self.comparators[self.key(event)] = equal
+	return self
+}
+
+// EmitChanged() - run the listeners of the event with the value as the only arg, in synchronous mode,
+// unless it's equal to the last value emitted by EmitChanged, suppressing the redundant state
+// notifications; the values are deep-equal compared unless SetComparator set the event's comparison.
+// Whether the value was emitted is returned
+func (self *Emitter) EmitChanged(event string, value interface{}) bool {
+	self.mutex.Lock()
+	key := self.key(event)
+	equal := self.comparators[key]
+	if equal == nil {
+		equal = reflect.DeepEqual
+	}
+	last, ok := self.values[key]
+	if ok && equal(last, value) {
+		self.mutex.Unlock()
+		return false
+	}
+	if self.values == nil {
+		self.values = make(map[string]interface{})
+	}
+	self.values[key] = value
+	self.mutex.Unlock()
+
+	self.EmitSync(event, value)
+	return true
+}
+
+// LastValue() - the last value emitted by EmitChanged for the event
+func (self *Emitter) LastValue(event string) (interface{}, bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	value, ok := self.values[self.key(event)]
+	return value, ok
+}
+
+// OnSticky() - register a new listener on the event and run it with its last value right away, if any,
+// so late subscribers of a configuration event get the current one
+func (self *Emitter) OnSticky(event string, callback func(...interface{})) *Subscription {
+	subscription := self.addListenerInternal(event, Listener{callback: callback})
+	if value, ok := self.LastValue(event); ok {
+		callback(value)
+	}
+	return subscription
+}
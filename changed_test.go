@@ -0,0 +1,28 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitChanged(t *testing.T) {
+	emitter := Construct()
+
+	count := 0
+	emitter.On("config", func(args ...interface{}) { count++ })
+
+	expect(t, true, emitter.EmitChanged("config", map[string]int{"workers": 4}))
+	expect(t, false, emitter.EmitChanged("config", map[string]int{"workers": 4}))
+	expect(t, true, emitter.EmitChanged("config", map[string]int{"workers": 8}))
+	expect(t, 2, count)
+
+	emitter.SetComparator("level", func(a, b interface{}) bool { return strings.EqualFold(a.(string), b.(string)) })
+	expect(t, true, emitter.EmitChanged("level", "info"))
+	expect(t, false, emitter.EmitChanged("level", "INFO"))
+
+	var sticky interface{}
+	emitter.OnSticky("level", func(args ...interface{}) { sticky = args[0] })
+	expect(t, "info", sticky)
+	emitter.EmitChanged("level", "debug")
+	expect(t, "debug", sticky)
+}
@@ -0,0 +1,47 @@
+package Emitter
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// Chunked() - run the listeners by chunks of size: for a sync emit the goroutine is yielded between the
+// chunks, so the emit of an event with thousands of listeners doesn't monopolize it, and for an async one
+// each chunk is a single task of the pool; progress, if not nil, is called after each chunk with the count
+// of the listeners run so far and their total
+func Chunked(size int, progress func(done, total int)) EmitOption {
+	return func(o *emitOptions) {
+		o.chunk = size
+		o.progress = progress
+	}
+}
+
+// runChunked() - run the listeners with the event by chunks, per the options
+func (self *Emitter) runChunked(ev *Event, listeners []Listener, options *emitOptions) {
+	total := len(listeners)
+	done := int64(0)
+	report := func(n int) {
+		n = int(atomic.AddInt64(&done, int64(n)))
+		if options.progress != nil {
+			options.progress(n, total)
+		}
+	}
+
+	for start := 0; start < total; start += options.chunk {
+		end := start + options.chunk
+		if end > total {
+			end = total
+		}
+		chunk := listeners[start:end]
+		if options.async {
+			self.submit(func() {
+				self.run(ev, chunk, false)
+				report(len(chunk))
+			}, ev.Priority)
+			continue
+		}
+		self.run(ev, chunk, false)
+		report(len(chunk))
+		runtime.Gosched()
+	}
+}
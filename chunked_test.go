@@ -0,0 +1,37 @@
+package Emitter
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestChunked(t *testing.T) {
+	pool := NewPool(2)
+	defer pool.Close()
+	emitter := New(WithPool(pool))
+	mutex := &sync.Mutex{}
+	ran := 0
+	for i := 0; i < 10; i++ {
+		emitter.On("cache.flush", func(args ...interface{}) {
+			mutex.Lock()
+			ran++
+			mutex.Unlock()
+		})
+	}
+
+	progress := []int{}
+	emitter.EmitWith("cache.flush", nil, Chunked(4, func(done, total int) {
+		expect(t, 10, total)
+		expect(t, done, ran, "the chunk ran before its progress")
+		progress = append(progress, done)
+	}))
+	expect(t, 10, ran)
+	expect(t, 3, len(progress))
+	expect(t, 10, progress[2])
+
+	wg := &sync.WaitGroup{}
+	wg.Add(3)
+	emitter.EmitWith("cache.flush", nil, Async(), Chunked(4, func(done, total int) { wg.Done() }))
+	wg.Wait()
+	expect(t, 20, ran, "the async chunks run in the pool")
+}
@@ -0,0 +1,158 @@
+package Emitter
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock - the time source of the time based features (delayed emits, timeouts, replays, leaks ...),
+// injectable with WithClock so the tests can use a FakeClock instead of real sleeps
+type Clock interface {
+	Now() time.Time
+	// AfterFunc - call f in its own goroutine once d elapsed, as time.AfterFunc does
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer - a pending call of a Clock's AfterFunc
+type Timer interface {
+	// Stop - cancel the call, false if it already happened or was canceled
+	Stop() bool
+}
+
+// SystemClock - the real time, the default clock
+var SystemClock Clock = systemClock{}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) AfterFunc(d time.Duration, f func()) Timer {
+	return time.AfterFunc(d, f)
+}
+
+// WithClock() - use the clock as the emitter's time source
+func WithClock(clock Clock) Option {
+	return func(e *Emitter) {
+		e.clock = clock
+	}
+}
+
+// Clock() - the emitter's time source
+func (self *Emitter) Clock() Clock {
+	if self.clock == nil {
+		return SystemClock
+	}
+	return self.clock
+}
+
+// sleep() - wait for d to elapse on the emitter's clock
+func (self *Emitter) sleep(d time.Duration) {
+	done := make(chan struct{})
+	self.Clock().AfterFunc(d, func() { close(done) })
+	<-done
+}
+
+// FakeClock - a Clock whose time only moves when told to, the calls due are made by Advance/Set
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+	seq    uint64
+	mutex  *sync.Mutex
+}
+
+type fakeTimer struct {
+	clock *FakeClock
+	at    time.Time
+	seq   uint64
+	f     func()
+	done  bool
+}
+
+// NewFakeClock() - create a new fake clock set at the time
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now, mutex: &sync.Mutex{}}
+}
+
+func (self *FakeClock) Now() time.Time {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.now
+}
+
+// AfterFunc() - call f once the clock is advanced by d, right away (in its own goroutine) if d <= 0
+func (self *FakeClock) AfterFunc(d time.Duration, f func()) Timer {
+	self.mutex.Lock()
+	self.seq++
+	timer := &fakeTimer{clock: self, at: self.now.Add(d), seq: self.seq, f: f}
+	if d <= 0 {
+		timer.done = true
+		self.mutex.Unlock()
+		go f()
+		return timer
+	}
+	self.timers = append(self.timers, timer)
+	self.mutex.Unlock()
+	return timer
+}
+
+// Advance() - move the clock forward by d, making the calls due meanwhile in their time order,
+// in the calling goroutine
+func (self *FakeClock) Advance(d time.Duration) {
+	self.Set(self.Now().Add(d))
+}
+
+// Set() - move the clock to the time, making the calls due meanwhile in their time order, in the calling goroutine
+func (self *FakeClock) Set(now time.Time) {
+	for {
+		self.mutex.Lock()
+		sort.Slice(self.timers, func(i, j int) bool {
+			if !self.timers[i].at.Equal(self.timers[j].at) {
+				return self.timers[i].at.Before(self.timers[j].at)
+			}
+			return self.timers[i].seq < self.timers[j].seq
+		})
+		if len(self.timers) == 0 || self.timers[0].at.After(now) {
+			if now.After(self.now) {
+				self.now = now
+			}
+			self.mutex.Unlock()
+			return
+		}
+		timer := self.timers[0]
+		self.timers = self.timers[1:]
+		timer.done = true
+		if timer.at.After(self.now) {
+			self.now = timer.at
+		}
+		self.mutex.Unlock()
+
+		timer.f()
+	}
+}
+
+// Pending() - the count of calls not made yet
+func (self *FakeClock) Pending() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return len(self.timers)
+}
+
+func (self *fakeTimer) Stop() bool {
+	self.clock.mutex.Lock()
+	defer self.clock.mutex.Unlock()
+
+	if self.done {
+		return false
+	}
+	self.done = true
+	for i, timer := range self.clock.timers {
+		if timer == self {
+			self.clock.timers = append(self.clock.timers[:i], self.clock.timers[i+1:]...)
+			break
+		}
+	}
+	return true
+}
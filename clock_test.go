@@ -0,0 +1,49 @@
+package Emitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	emitter := New(WithClock(clock))
+
+	fired := []string{}
+	emitter.On("reminder", func(args ...interface{}) { fired = append(fired, args[0].(string)) })
+	emitter.EmitAfter(2*time.Hour, "reminder", "second")
+	emitter.EmitAfter(time.Hour, "reminder", "first")
+	emitter.EmitAfter(3*time.Hour, "reminder", "canceled").Cancel()
+
+	clock.Advance(90 * time.Minute)
+	expect(t, 1, len(fired))
+	clock.Advance(time.Hour)
+	expect(t, 2, len(fired))
+	expect(t, "second", fired[1])
+	expect(t, 0, clock.Pending())
+	expect(t, time.Date(2020, 1, 1, 2, 30, 0, 0, time.UTC), clock.Now())
+}
+
+func TestFakeClockTimeout(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	emitter := New(WithClock(clock))
+
+	started, release := make(chan struct{}), make(chan struct{})
+	emitter.On("slow", func(args ...interface{}) {
+		close(started)
+		<-release
+	})
+	emitter.On("slow", func(args ...interface{}) {})
+
+	result := make(chan error)
+	go func() { result <- emitter.EmitSyncTimeout("slow", time.Second) }()
+	<-started
+	for clock.Pending() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Second)
+
+	err := <-result
+	expect(t, 1, len(err.(*TimeoutError).Skipped))
+	close(release)
+}
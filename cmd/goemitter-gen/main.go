@@ -0,0 +1,153 @@
+// Command goemitter-gen generates typed wrappers over an emitter, so call sites
+// emit and listen through methods instead of event name strings.
+//
+//	//go:generate goemitter-gen -package users -output events_gen.go user.created=User user.deleted=*UserDeleted
+//
+// generates, for every event=PayloadType pair,
+//
+//	func (self Events) EmitUserCreated(payload User)
+//	func (self Events) OnUserCreated(callback func(User)) *Emitter.Subscription
+//
+// on an `Events` type wrapping the emitter, created with NewEvents(emitter).
+// Payload types of other packages need their import: -import github.com/acme/models
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// Event - an event to generate the wrappers of
+type Event struct {
+	Name    string
+	Method  string
+	Payload string
+}
+
+// Config - what to generate
+type Config struct {
+	Package string
+	Type    string
+	Imports []string
+	Events  []Event
+}
+
+var source = template.Must(template.New("source").Parse(`// Code generated by goemitter-gen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+	Emitter "github.com/moleculer-go/goemitter"
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+
+// {{.Type}} - the typed events of an emitter
+type {{.Type}} struct {
+	Emitter *Emitter.Emitter
+}
+
+// New{{.Type}}() - wrap the emitter with its typed events
+func New{{.Type}}(e *Emitter.Emitter) {{.Type}} {
+	return {{.Type}}{e}
+}
+{{range .Events}}
+// Emit{{.Method}}() - emit "{{.Name}}" in synchronous mode
+func (self {{$.Type}}) Emit{{.Method}}(payload {{.Payload}}) {
+	self.Emitter.EmitSync("{{.Name}}", payload)
+}
+
+// On{{.Method}}() - listen on "{{.Name}}", emits whose payload isn't a {{.Payload}} are ignored
+func (self {{$.Type}}) On{{.Method}}(callback func({{.Payload}})) *Emitter.Subscription {
+	return self.Emitter.OnEvent("{{.Name}}", func(ev *Emitter.Event) {
+		if len(ev.Args) > 0 {
+			if payload, ok := ev.Args[0].({{.Payload}}); ok {
+				callback(payload)
+			}
+		}
+	})
+}
+{{end}}`))
+
+// Generate() - the formatted source of the wrappers
+func Generate(config Config) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := source.Execute(buf, config); err != nil {
+		return nil, err
+	}
+	return format.Source(buf.Bytes())
+}
+
+// ParseEvent() - parse an "event.name=PayloadType" argument
+func ParseEvent(arg string) (Event, error) {
+	parts := strings.SplitN(arg, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return Event{}, fmt.Errorf("invalid event %q, expected event.name=PayloadType", arg)
+	}
+	if strings.ContainsAny(parts[0], "*\"\\") {
+		return Event{}, fmt.Errorf("invalid event name %q, patterns can't be typed", parts[0])
+	}
+	return Event{Name: parts[0], Method: MethodName(parts[0]), Payload: parts[1]}, nil
+}
+
+// MethodName() - the exported method suffix of the event name, "user.created" is "UserCreated"
+func MethodName(event string) string {
+	words := strings.FieldsFunc(event, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	name := ""
+	for _, word := range words {
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+		name += string(runes)
+	}
+	return name
+}
+
+func main() {
+	config := Config{}
+	output := flag.String("output", "", "the generated file, stdout if empty")
+	imports := flag.String("import", "", "the comma separated imports of the payload types")
+	flag.StringVar(&config.Package, "package", os.Getenv("GOPACKAGE"), "the package of the generated file")
+	flag.StringVar(&config.Type, "type", "Events", "the name of the generated wrapper type")
+	flag.Parse()
+
+	if *imports != "" {
+		config.Imports = strings.Split(*imports, ",")
+	}
+	for _, arg := range flag.Args() {
+		event, err := ParseEvent(arg)
+		if err != nil {
+			fail(err)
+		}
+		config.Events = append(config.Events, event)
+	}
+	if config.Package == "" || len(config.Events) == 0 {
+		fail(fmt.Errorf("usage: goemitter-gen -package name [-type Events] [-output file] event.name=PayloadType ..."))
+	}
+
+	src, err := Generate(config)
+	if err != nil {
+		fail(err)
+	}
+	if *output == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fail(err)
+	}
+}
+
+func fail(err error) {
+	fmt.Fprintln(os.Stderr, "goemitter-gen:", err)
+	os.Exit(1)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	created, _ := ParseEvent("user.created=User")
+	deleted, _ := ParseEvent("user-deleted=*models.UserDeleted")
+
+	src, err := Generate(Config{
+		Package: "users",
+		Type:    "Events",
+		Imports: []string{"github.com/acme/models"},
+		Events:  []Event{created, deleted},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		"package users",
+		`"github.com/acme/models"`,
+		"func (self Events) EmitUserCreated(payload User) {",
+		`self.Emitter.EmitSync("user.created", payload)`,
+		"func (self Events) OnUserDeleted(callback func(*models.UserDeleted)) *Emitter.Subscription {",
+	} {
+		if !strings.Contains(string(src), expected) {
+			t.Errorf("expected the generated source to contain %q:\n%s", expected, src)
+		}
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	for _, arg := range []string{"user.created", "=User", "user.*=User"} {
+		if _, err := ParseEvent(arg); err == nil {
+			t.Errorf("expected %q to be rejected", arg)
+		}
+	}
+	if name := MethodName("sys.signal_TERM"); name != "SysSignalTERM" {
+		t.Errorf("unexpected method name %s", name)
+	}
+}
@@ -0,0 +1,31 @@
+// Package codec serializes event envelopes for the transport bridges.
+package codec
+
+import (
+	"encoding/json"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Codec - marshal/unmarshal event envelopes to/from their wire format
+type Codec interface {
+	Marshal(ev *Emitter.Event) ([]byte, error)
+	Unmarshal(data []byte) (*Emitter.Event, error)
+}
+
+// JSON - the json codec, the default of the bridges
+var JSON Codec = jsonCodec{}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+func (jsonCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	ev := &Emitter.Event{}
+	if err := json.Unmarshal(data, ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
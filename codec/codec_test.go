@@ -0,0 +1,121 @@
+package codec
+
+import (
+	"encoding/gob"
+	"reflect"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestRoundTrip(t *testing.T) {
+	ev := &Emitter.Event{
+		Name:    "user.created",
+		Args:    []interface{}{"john", 1.5, true, nil, []interface{}{"a"}, map[string]interface{}{"k": "v"}},
+		Headers: map[string]string{Emitter.HeaderOrigin: "node-1"},
+	}
+
+	for name, c := range map[string]Codec{"json": JSON, "msgpack": Msgpack, "gob": Gob, "protobuf": Protobuf} {
+		data, err := c.Marshal(ev)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		got, err := c.Unmarshal(data)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !reflect.DeepEqual(ev, got) {
+			t.Errorf("%s: Expected %#v - Got %#v", name, ev, got)
+		}
+	}
+}
+
+type user struct {
+	Name string
+	Age  int
+}
+
+func TestTypedArgs(t *testing.T) {
+	gob.Register(user{})
+
+	ev := &Emitter.Event{Name: "user.created", Args: []interface{}{int64(-42), uint64(42), user{"john", 42}}}
+
+	data, _ := Gob.Marshal(ev)
+	got, err := Gob.Unmarshal(data)
+	if err != nil || !reflect.DeepEqual(ev, got) {
+		t.Errorf("gob: Expected %#v - Got %#v (%v)", ev, got, err)
+	}
+
+	// msgpack keeps the integers but encodes the structs through json
+	data, _ = Msgpack.Marshal(ev)
+	got, err = Msgpack.Unmarshal(data)
+	expected := []interface{}{int64(-42), uint64(42), map[string]interface{}{"Name": "john", "Age": 42.0}}
+	if err != nil || !reflect.DeepEqual(expected, got.Args) {
+		t.Errorf("msgpack: Expected %#v - Got %#v (%v)", expected, got.Args, err)
+	}
+}
+
+func TestJSON(t *testing.T) {
+	data, err := JSON.Marshal(&Emitter.Event{Name: "user.created", Args: []interface{}{"john"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != `{"name":"user.created","args":["john"]}` {
+		t.Errorf("unexpected json %s", data)
+	}
+}
+
+func TestMsgpackInvalid(t *testing.T) {
+	for _, data := range [][]byte{nil, {0xdc, 0xff, 0xff}, {0xa5, 'a'}, {0x93, 0x01}} {
+		if _, err := Msgpack.Unmarshal(data); err == nil {
+			t.Errorf("expected an error decoding %x", data)
+		}
+	}
+}
+
+func TestProtobufWireFormat(t *testing.T) {
+	data, _ := Protobuf.Marshal(&Emitter.Event{Name: "a", Args: []interface{}{1.0}, Headers: map[string]string{"k": "v"}})
+
+	// name = "a", args = ["1"], headers = {k: v}
+	expected := []byte{0x0a, 1, 'a', 0x12, 1, '1', 0x1a, 6, 0x0a, 1, 'k', 0x12, 1, 'v'}
+	if !reflect.DeepEqual(expected, data) {
+		t.Errorf("Expected %x - Got %x", expected, data)
+	}
+
+	// unknown varint/fixed fields are skipped
+	ev, err := Protobuf.Unmarshal(append([]byte{0x20, 0x96, 0x01, 0x2d, 1, 2, 3, 4}, data...))
+	if err != nil || ev.Name != "a" || ev.Headers["k"] != "v" {
+		t.Errorf("unexpected event %#v (%v)", ev, err)
+	}
+
+	if _, err := Protobuf.Unmarshal([]byte{0x0a, 5, 'a'}); err == nil {
+		t.Errorf("expected an error decoding a truncated message")
+	}
+}
+
+func TestMoleculerPacket(t *testing.T) {
+	c := Moleculer{NodeID: "node-1"}
+	data, _ := c.Marshal(&Emitter.Event{
+		Name:    "user.created",
+		Args:    []interface{}{map[string]interface{}{"name": "john"}},
+		Headers: map[string]string{HeaderGroups: "users,mail", Emitter.HeaderOrigin: "bridge-1"},
+	})
+
+	expected := `{"ver":"4","sender":"node-1","event":"user.created","data":{"name":"john"},"groups":["users","mail"],"broadcast":false,"meta":{"origin":"bridge-1"},"level":1}`
+	if string(data) != expected {
+		t.Errorf("Expected %s - Got %s", expected, data)
+	}
+
+	ev, err := c.Unmarshal([]byte(`{"ver":"4","sender":"node-2","id":"42","event":"order.paid","data":[1,2],"broadcast":true,"meta":{"trace":{"a":1}},"level":1}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	headers := map[string]string{HeaderNodeID: "node-2", HeaderPacketID: "42", HeaderBroadcast: "true", "trace": `{"a":1}`}
+	if ev.Name != "order.paid" || !reflect.DeepEqual([]interface{}{[]interface{}{1.0, 2.0}}, ev.Args) || !reflect.DeepEqual(headers, ev.Headers) {
+		t.Errorf("unexpected event %#v", ev)
+	}
+
+	if _, err := c.Unmarshal([]byte(`{"ver":"4"}`)); err != ErrMoleculerPacket {
+		t.Errorf("expected ErrMoleculerPacket, got %v", err)
+	}
+}
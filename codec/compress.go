@@ -0,0 +1,245 @@
+package codec
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"io/ioutil"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Compression - the algorithm compressing the payloads of the Compressed codec
+type Compression byte
+
+const (
+	// Gzip - the gzip compression, the smallest payloads
+	Gzip Compression = iota + 1
+	// Snappy - the snappy block compression, the fastest one, written by hand like the protobuf codec
+	Snappy
+)
+
+// ErrCompressedInvalid - the data isn't a valid payload of the Compressed codec
+var ErrCompressedInvalid = errors.New("codec: invalid compressed data")
+
+// Compressed() - the codec compressing the payloads of the codec reaching threshold bytes with the compression,
+// the smaller ones aren't worth it and are sent as they are.
+// The payloads start with a byte telling their compression, so every bridge of the events must use
+// a Compressed codec, but they can use different compressions
+func Compressed(codec Codec, compression Compression, threshold int) Codec {
+	return compressedCodec{codec, compression, threshold}
+}
+
+type compressedCodec struct {
+	codec       Codec
+	compression Compression
+	threshold   int
+}
+
+func (self compressedCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	data, err := self.codec.Marshal(ev)
+	if err != nil || len(data) < self.threshold {
+		return append([]byte{0}, data...), err
+	}
+
+	var compressed []byte
+	switch self.compression {
+	case Gzip:
+		buf := &bytes.Buffer{}
+		w := gzip.NewWriter(buf)
+		if _, err := w.Write(data); err != nil {
+			return nil, err
+		}
+		if err := w.Close(); err != nil {
+			return nil, err
+		}
+		compressed = buf.Bytes()
+	case Snappy:
+		compressed = snappyEncode(data)
+	}
+	// not compressible, i.e already compressed args
+	if compressed == nil || len(compressed) >= len(data) {
+		return append([]byte{0}, data...), nil
+	}
+	return append([]byte{byte(self.compression)}, compressed...), nil
+}
+
+func (self compressedCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	if len(data) == 0 {
+		return nil, ErrCompressedInvalid
+	}
+
+	payload := data[1:]
+	switch Compression(data[0]) {
+	case 0:
+	case Gzip:
+		r, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		if payload, err = ioutil.ReadAll(r); err != nil {
+			return nil, err
+		}
+	case Snappy:
+		var err error
+		if payload, err = snappyDecode(payload); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrCompressedInvalid
+	}
+	return self.codec.Unmarshal(payload)
+}
+
+// the tags of the snappy block format elements
+const (
+	snappyLiteral = 0
+	snappyCopy1   = 1
+	snappyCopy2   = 2
+	snappyCopy4   = 3
+
+	snappyTableBits = 14
+	snappyMaxOffset = 1<<16 - 1
+)
+
+// snappyEncode() - the snappy block of the data: its length, then literals and copies of the previous
+// bytes found by hashing every 4 bytes sequence
+func snappyEncode(data []byte) []byte {
+	dst := make([]byte, binary.MaxVarintLen64, len(data)/2+binary.MaxVarintLen64)
+	dst = dst[:binary.PutUvarint(dst, uint64(len(data)))]
+
+	table := make([]int32, 1<<snappyTableBits)
+	for i := range table {
+		table[i] = -1
+	}
+
+	literal := 0
+	for i := 0; i+4 <= len(data); {
+		sequence := binary.LittleEndian.Uint32(data[i:])
+		h := (sequence * 0x1e35a7bd) >> (32 - snappyTableBits)
+		candidate := int(table[h])
+		table[h] = int32(i)
+		if candidate < 0 || i-candidate > snappyMaxOffset || binary.LittleEndian.Uint32(data[candidate:]) != sequence {
+			i++
+			continue
+		}
+
+		dst = appendSnappyLiteral(dst, data[literal:i])
+		length := 4
+		for i+length < len(data) && data[candidate+length] == data[i+length] {
+			length++
+		}
+		dst = appendSnappyCopy(dst, i-candidate, length)
+		i += length
+		literal = i
+	}
+	return appendSnappyLiteral(dst, data[literal:])
+}
+
+// appendSnappyLiteral() - append the literal element of the bytes
+func appendSnappyLiteral(dst []byte, literal []byte) []byte {
+	n := len(literal) - 1
+	switch {
+	case n < 0:
+		return dst
+	case n < 60:
+		dst = append(dst, byte(n)<<2|snappyLiteral)
+	case n < 1<<8:
+		dst = append(dst, 60<<2|snappyLiteral, byte(n))
+	case n < 1<<16:
+		dst = append(dst, 61<<2|snappyLiteral, byte(n), byte(n>>8))
+	case n < 1<<24:
+		dst = append(dst, 62<<2|snappyLiteral, byte(n), byte(n>>8), byte(n>>16))
+	default:
+		dst = append(dst, 63<<2|snappyLiteral, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+	}
+	return append(dst, literal...)
+}
+
+// appendSnappyCopy() - append the copy elements of the length bytes at the offset, 64 bytes at most each
+func appendSnappyCopy(dst []byte, offset, length int) []byte {
+	for length > 0 {
+		n := length
+		if n > 64 {
+			n = 64
+		}
+		dst = append(dst, byte(n-1)<<2|snappyCopy2, byte(offset), byte(offset>>8))
+		length -= n
+	}
+	return dst
+}
+
+// snappyDecode() - the data of the snappy block
+func snappyDecode(src []byte) ([]byte, error) {
+	length, n := binary.Uvarint(src)
+	if n <= 0 || length > uint64(len(src))*256 {
+		return nil, ErrCompressedInvalid
+	}
+	src = src[n:]
+	dst := make([]byte, 0, length)
+
+	for len(src) > 0 {
+		tag := src[0]
+		src = src[1:]
+
+		var offset, size int
+		switch tag & 3 {
+		case snappyLiteral:
+			size = int(tag >> 2)
+			if size >= 60 {
+				extra := size - 59
+				if len(src) < extra {
+					return nil, ErrCompressedInvalid
+				}
+				size = 0
+				for i := extra - 1; i >= 0; i-- {
+					size = size<<8 | int(src[i])
+				}
+				src = src[extra:]
+			}
+			size++
+			if size <= 0 || len(src) < size {
+				return nil, ErrCompressedInvalid
+			}
+			dst = append(dst, src[:size]...)
+			src = src[size:]
+			continue
+		case snappyCopy1:
+			if len(src) < 1 {
+				return nil, ErrCompressedInvalid
+			}
+			size = 4 + int(tag>>2&7)
+			offset = int(tag&0xe0)<<3 | int(src[0])
+			src = src[1:]
+		case snappyCopy2:
+			if len(src) < 2 {
+				return nil, ErrCompressedInvalid
+			}
+			size = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint16(src))
+			src = src[2:]
+		case snappyCopy4:
+			if len(src) < 4 {
+				return nil, ErrCompressedInvalid
+			}
+			size = 1 + int(tag>>2)
+			offset = int(binary.LittleEndian.Uint32(src))
+			src = src[4:]
+		}
+
+		if offset <= 0 || offset > len(dst) {
+			return nil, ErrCompressedInvalid
+		}
+		// the copies may overlap the bytes they produce, so byte by byte
+		start := len(dst) - offset
+		for i := 0; i < size; i++ {
+			dst = append(dst, dst[start+i])
+		}
+	}
+
+	if uint64(len(dst)) != length {
+		return nil, ErrCompressedInvalid
+	}
+	return dst, nil
+}
@@ -0,0 +1,74 @@
+package codec
+
+import (
+	"bytes"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestCompressed(t *testing.T) {
+	large := &Emitter.Event{Name: "user.created", Args: []interface{}{strings.Repeat("john doe ", 200)}}
+	small := &Emitter.Event{Name: "user.created", Args: []interface{}{"john"}}
+
+	for name, compression := range map[string]Compression{"gzip": Gzip, "snappy": Snappy} {
+		c := Compressed(JSON, compression, 256)
+		plain, _ := JSON.Marshal(large)
+
+		data, err := c.Marshal(large)
+		if err != nil || data[0] != byte(compression) || len(data) >= len(plain)/4 {
+			t.Errorf("%s: Expected the large payload compressed - Got %d bytes of %d (%v)", name, len(data), len(plain), err)
+		}
+		got, err := c.Unmarshal(data)
+		if err != nil || !reflect.DeepEqual(large, got) {
+			t.Errorf("%s: Expected %#v - Got %#v (%v)", name, large, got, err)
+		}
+
+		data, _ = c.Marshal(small)
+		if data[0] != 0 {
+			t.Errorf("%s: Expected the small payload below the threshold sent as it is", name)
+		}
+		if got, err = c.Unmarshal(data); err != nil || !reflect.DeepEqual(small, got) {
+			t.Errorf("%s: Expected %#v - Got %#v (%v)", name, small, got, err)
+		}
+
+		// any compression is decoded
+		data, _ = Compressed(JSON, Gzip+Snappy-compression, 0).Marshal(large)
+		if got, err = c.Unmarshal(data); err != nil || !reflect.DeepEqual(large, got) {
+			t.Errorf("%s: Expected the other compression decoded - Got %#v (%v)", name, got, err)
+		}
+	}
+
+	if _, err := Compressed(JSON, Gzip, 0).Unmarshal([]byte{9, '{', '}'}); err != ErrCompressedInvalid {
+		t.Errorf("Expected %v - Got %v", ErrCompressedInvalid, err)
+	}
+}
+
+func TestSnappy(t *testing.T) {
+	random := make([]byte, 5000)
+	rand.New(rand.NewSource(1)).Read(random)
+	inputs := [][]byte{
+		{}, []byte("a"), []byte("abcd"), bytes.Repeat([]byte("a"), 1000),
+		bytes.Repeat([]byte("abcdefgh"), 10000), random, append(random, random...),
+	}
+	for _, input := range inputs {
+		got, err := snappyDecode(snappyEncode(input))
+		if err != nil || !bytes.Equal(input, got) {
+			t.Errorf("Expected the %d bytes back - Got %d bytes (%v)", len(input), len(got), err)
+		}
+	}
+
+	// the elements written by the other encoders: a literal, a 1 byte offset copy and an overlapping 4 bytes offset copy
+	block := []byte{10, 1 << 2, 'a', 'b', 0<<2 | snappyCopy1, 2, 3<<2 | snappyCopy4, 1, 0, 0, 0}
+	if got, err := snappyDecode(block); err != nil || string(got) != "abababbbbb" {
+		t.Errorf("Expected abababbbbb - Got %q (%v)", got, err)
+	}
+	for _, invalid := range [][]byte{{}, {4, 2 << 2}, {4, 0 << 2, 'a', snappyCopy2, 2, 0}, {2, 0 << 2, 'a'}} {
+		if _, err := snappyDecode(invalid); err != ErrCompressedInvalid {
+			t.Errorf("Expected %v for %v - Got %v", ErrCompressedInvalid, invalid, err)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+// Code generated by gen_protobuf.go from event.proto. DO NOT EDIT.
+
+package codec
+
+// the field numbers of the Event message of event.proto
+const (
+	protoName    = 1 // string name
+	protoArgs    = 2 // bytes args
+	protoHeaders = 3 // map<string, string> headers
+
+	// the fields of the entries of the maps, fixed by the protobuf encoding
+	protoMapKey   = 1
+	protoMapValue = 2
+)
@@ -0,0 +1,38 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/gob"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Gob - the gob codec, for bridges between go processes only
+//
+// gob transmits the args as interfaces, so any concrete type other than the
+// basic ones, []interface{} and map[string]interface{} must be registered
+// with gob.Register() on both sides before being emitted through a bridge
+var Gob Codec = gobCodec{}
+
+func init() {
+	gob.Register([]interface{}{})
+	gob.Register(map[string]interface{}{})
+}
+
+type gobCodec struct{}
+
+func (gobCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(ev); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	ev := &Emitter.Event{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(ev); err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
@@ -0,0 +1,136 @@
+package codec
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// the headers mapped to the fields of the moleculer event packet
+const (
+	// HeaderNodeID - the id of the moleculer node that sent the event, the packet's "sender"
+	HeaderNodeID = "moleculer.nodeID"
+	// HeaderGroups - the comma separated groups the event is balanced to, the packet's "groups"
+	HeaderGroups = "moleculer.groups"
+	// HeaderBroadcast - "true" if the event is broadcast, the packet's "broadcast"
+	HeaderBroadcast = "moleculer.broadcast"
+	// HeaderPacketID - the id of the packet, the packet's "id"
+	HeaderPacketID = "moleculer.id"
+)
+
+// MoleculerProtocol - the version of the moleculer protocol of the packets
+const MoleculerProtocol = "4"
+
+// ErrMoleculerPacket - the data isn't a moleculer event packet
+var ErrMoleculerPacket = errors.New("codec: not a moleculer event packet")
+
+// MoleculerPacket - the EVENT packet of the moleculer protocol
+type MoleculerPacket struct {
+	Ver       string                 `json:"ver"`
+	Sender    string                 `json:"sender"`
+	ID        string                 `json:"id,omitempty"`
+	Event     string                 `json:"event"`
+	Data      interface{}            `json:"data"`
+	Groups    []string               `json:"groups,omitempty"`
+	Broadcast bool                   `json:"broadcast"`
+	Meta      map[string]interface{} `json:"meta"`
+	Level     int                    `json:"level"`
+}
+
+// Moleculer - the codec of the moleculer EVENT packets, for the events to flow to/from a moleculer
+// broker's transport as they are
+//
+// moleculer events carry one payload: no arg is sent as a null data, one arg as the data itself
+// and several args as an array; the received data is the only arg of the event, unless null.
+// The moleculer headers are mapped to the packet's fields, the other ones to its meta
+type Moleculer struct {
+	// NodeID - the sender of the packets whose event has no HeaderNodeID
+	NodeID string
+}
+
+func (self Moleculer) Marshal(ev *Emitter.Event) ([]byte, error) {
+	return json.Marshal(EncodeMoleculer(ev, self.NodeID))
+}
+
+func (self Moleculer) Unmarshal(data []byte) (*Emitter.Event, error) {
+	packet := &MoleculerPacket{}
+	if err := json.Unmarshal(data, packet); err != nil {
+		return nil, err
+	}
+	if packet.Event == "" {
+		return nil, ErrMoleculerPacket
+	}
+	return DecodeMoleculer(packet), nil
+}
+
+// EncodeMoleculer() - the moleculer EVENT packet of the event, sent by the node unless it has a HeaderNodeID
+func EncodeMoleculer(ev *Emitter.Event, nodeID string) *MoleculerPacket {
+	packet := &MoleculerPacket{
+		Ver:    MoleculerProtocol,
+		Sender: nodeID,
+		Event:  ev.Name,
+		Meta:   map[string]interface{}{},
+		Level:  1,
+	}
+
+	switch len(ev.Args) {
+	case 0:
+	case 1:
+		packet.Data = ev.Args[0]
+	default:
+		packet.Data = ev.Args
+	}
+
+	for k, v := range ev.Headers {
+		switch k {
+		case HeaderNodeID:
+			packet.Sender = v
+		case HeaderGroups:
+			if v != "" {
+				packet.Groups = strings.Split(v, ",")
+			}
+		case HeaderBroadcast:
+			packet.Broadcast, _ = strconv.ParseBool(v)
+		case HeaderPacketID:
+			packet.ID = v
+		default:
+			packet.Meta[k] = v
+		}
+	}
+	return packet
+}
+
+// DecodeMoleculer() - the event of the moleculer EVENT packet
+func DecodeMoleculer(packet *MoleculerPacket) *Emitter.Event {
+	ev := &Emitter.Event{Name: packet.Event, Headers: map[string]string{}}
+	if packet.Data != nil {
+		ev.Args = []interface{}{packet.Data}
+	}
+
+	if packet.Sender != "" {
+		ev.Headers[HeaderNodeID] = packet.Sender
+	}
+	if len(packet.Groups) > 0 {
+		ev.Headers[HeaderGroups] = strings.Join(packet.Groups, ",")
+	}
+	if packet.Broadcast {
+		ev.Headers[HeaderBroadcast] = "true"
+	}
+	if packet.ID != "" {
+		ev.Headers[HeaderPacketID] = packet.ID
+	}
+	for k, v := range packet.Meta {
+		if s, ok := v.(string); ok {
+			ev.Headers[k] = s
+		} else if b, err := json.Marshal(v); err == nil {
+			ev.Headers[k] = string(b)
+		} else {
+			ev.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	return ev
+}
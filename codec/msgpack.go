@@ -0,0 +1,300 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Msgpack - the msgpack codec, the envelope is encoded as a map of its "name", "args" and "headers"
+//
+// args are decoded to nil, bool, int64, uint64, float64, string, []byte,
+// []interface{} and map[string]interface{}, other types are encoded through their json representation
+var Msgpack Codec = msgpackCodec{}
+
+// ErrMsgpackInvalid - the data isn't a valid msgpack envelope
+var ErrMsgpackInvalid = errors.New("codec: invalid msgpack data")
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	headers := make(map[string]interface{}, len(ev.Headers))
+	for k, v := range ev.Headers {
+		headers[k] = v
+	}
+	args := ev.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+
+	w := &msgpackWriter{}
+	err := w.write(map[string]interface{}{
+		"name":    ev.Name,
+		"args":    args,
+		"headers": headers,
+	})
+	return w.buf, err
+}
+
+func (msgpackCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	r := &msgpackReader{buf: data}
+	v, err := r.read()
+	if err != nil {
+		return nil, err
+	}
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, ErrMsgpackInvalid
+	}
+
+	ev := &Emitter.Event{}
+	ev.Name, _ = m["name"].(string)
+	ev.Args, _ = m["args"].([]interface{})
+	if headers, ok := m["headers"].(map[string]interface{}); ok && len(headers) > 0 {
+		ev.Headers = make(map[string]string, len(headers))
+		for k, v := range headers {
+			ev.Headers[k] = fmt.Sprint(v)
+		}
+	}
+	return ev, nil
+}
+
+// msgpackWriter - a minimal msgpack encoder
+type msgpackWriter struct {
+	buf []byte
+}
+
+func (self *msgpackWriter) byte(b ...byte) {
+	self.buf = append(self.buf, b...)
+}
+
+func (self *msgpackWriter) uint(prefix byte, size int, v uint64) {
+	self.byte(prefix)
+	for i := size - 1; i >= 0; i-- {
+		self.byte(byte(v >> (uint(i) * 8)))
+	}
+}
+
+func (self *msgpackWriter) length(fix, fixMax byte, b8, b16, b32 byte, n int) {
+	switch {
+	case fix != 0 && n <= int(fixMax):
+		self.byte(fix | byte(n))
+	case b8 != 0 && n <= math.MaxUint8:
+		self.uint(b8, 1, uint64(n))
+	case n <= math.MaxUint16:
+		self.uint(b16, 2, uint64(n))
+	default:
+		self.uint(b32, 4, uint64(n))
+	}
+}
+
+func (self *msgpackWriter) write(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		self.byte(0xc0)
+	case bool:
+		if v {
+			self.byte(0xc3)
+		} else {
+			self.byte(0xc2)
+		}
+	case string:
+		self.length(0xa0, 31, 0xd9, 0xda, 0xdb, len(v))
+		self.byte([]byte(v)...)
+	case []byte:
+		self.length(0, 0, 0xc4, 0xc5, 0xc6, len(v))
+		self.byte(v...)
+	case float32:
+		self.uint(0xca, 4, uint64(math.Float32bits(v)))
+	case float64:
+		self.uint(0xcb, 8, math.Float64bits(v))
+	case int, int8, int16, int32, int64:
+		self.int(reflect.ValueOf(v).Int())
+	case uint, uint8, uint16, uint32, uint64, uintptr:
+		self.uint(0xcf, 8, reflect.ValueOf(v).Uint())
+	case []interface{}:
+		self.length(0x90, 15, 0, 0xdc, 0xdd, len(v))
+		for _, item := range v {
+			if err := self.write(item); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		self.length(0x80, 15, 0, 0xde, 0xdf, len(v))
+		for k, item := range v {
+			self.write(k)
+			if err := self.write(item); err != nil {
+				return err
+			}
+		}
+	default:
+		// anything else goes through its json representation
+		data, err := json.Marshal(v)
+		if err != nil {
+			return err
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return err
+		}
+		return self.write(generic)
+	}
+	return nil
+}
+
+func (self *msgpackWriter) int(v int64) {
+	switch {
+	case v >= 0 && v <= 127:
+		self.byte(byte(v))
+	case v < 0 && v >= -32:
+		self.byte(byte(v))
+	default:
+		self.uint(0xd3, 8, uint64(v))
+	}
+}
+
+// msgpackReader - a minimal msgpack decoder
+type msgpackReader struct {
+	buf []byte
+	pos int
+}
+
+func (self *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || self.pos+n > len(self.buf) {
+		return nil, ErrMsgpackInvalid
+	}
+	b := self.buf[self.pos : self.pos+n]
+	self.pos += n
+	return b, nil
+}
+
+func (self *msgpackReader) uint(size int) (uint64, error) {
+	b, err := self.next(size)
+	if err != nil {
+		return 0, err
+	}
+	switch size {
+	case 1:
+		return uint64(b[0]), nil
+	case 2:
+		return uint64(binary.BigEndian.Uint16(b)), nil
+	case 4:
+		return uint64(binary.BigEndian.Uint32(b)), nil
+	default:
+		return binary.BigEndian.Uint64(b), nil
+	}
+}
+
+func (self *msgpackReader) read() (interface{}, error) {
+	b, err := self.next(1)
+	if err != nil {
+		return nil, err
+	}
+	c := b[0]
+
+	switch {
+	case c <= 0x7f:
+		return int64(c), nil
+	case c >= 0xe0:
+		return int64(int8(c)), nil
+	case c&0xe0 == 0xa0:
+		return self.str(int(c & 0x1f))
+	case c&0xf0 == 0x90:
+		return self.array(int(c & 0x0f))
+	case c&0xf0 == 0x80:
+		return self.dict(int(c & 0x0f))
+	}
+
+	switch c {
+	case 0xc0:
+		return nil, nil
+	case 0xc2:
+		return false, nil
+	case 0xc3:
+		return true, nil
+	case 0xc4, 0xc5, 0xc6:
+		n, err := self.uint(1 << (c - 0xc4))
+		if err != nil {
+			return nil, err
+		}
+		data, err := self.next(int(n))
+		return append([]byte{}, data...), err
+	case 0xca:
+		v, err := self.uint(4)
+		return float64(math.Float32frombits(uint32(v))), err
+	case 0xcb:
+		v, err := self.uint(8)
+		return math.Float64frombits(v), err
+	case 0xcc, 0xcd, 0xce, 0xcf:
+		return self.uint(1 << (c - 0xcc))
+	case 0xd0, 0xd1, 0xd2, 0xd3:
+		size := 1 << (c - 0xd0)
+		v, err := self.uint(size)
+		shift := uint(64 - size*8)
+		return int64(v<<shift) >> shift, err
+	case 0xd9, 0xda, 0xdb:
+		n, err := self.uint(1 << (c - 0xd9))
+		if err != nil {
+			return nil, err
+		}
+		return self.str(int(n))
+	case 0xdc, 0xdd:
+		n, err := self.uint(2 << (c - 0xdc))
+		if err != nil {
+			return nil, err
+		}
+		return self.array(int(n))
+	case 0xde, 0xdf:
+		n, err := self.uint(2 << (c - 0xde))
+		if err != nil {
+			return nil, err
+		}
+		return self.dict(int(n))
+	}
+
+	return nil, ErrMsgpackInvalid
+}
+
+func (self *msgpackReader) str(n int) (interface{}, error) {
+	b, err := self.next(n)
+	return string(b), err
+}
+
+func (self *msgpackReader) array(n int) (interface{}, error) {
+	if n > len(self.buf)-self.pos {
+		return nil, ErrMsgpackInvalid
+	}
+	arr := make([]interface{}, n)
+	for i := range arr {
+		v, err := self.read()
+		if err != nil {
+			return nil, err
+		}
+		arr[i] = v
+	}
+	return arr, nil
+}
+
+func (self *msgpackReader) dict(n int) (interface{}, error) {
+	if n > len(self.buf)-self.pos {
+		return nil, ErrMsgpackInvalid
+	}
+	m := make(map[string]interface{}, n)
+	for i := 0; i < n; i++ {
+		k, err := self.read()
+		if err != nil {
+			return nil, err
+		}
+		v, err := self.read()
+		if err != nil {
+			return nil, err
+		}
+		m[fmt.Sprint(k)] = v
+	}
+	return m, nil
+}
@@ -0,0 +1,151 @@
+package codec
+
+//go:generate go run gen_protobuf.go
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Protobuf - the protobuf codec of the envelope defined in event.proto
+//
+// the wire format is written by hand so the package doesn't depend on the protobuf runtime, its
+// field numbers are generated from event.proto; args are json encoded one by one, so they are
+// decoded like the JSON codec does
+var Protobuf Codec = protobufCodec{}
+
+// ErrProtobufInvalid - the data isn't a valid protobuf envelope
+var ErrProtobufInvalid = errors.New("codec: invalid protobuf data")
+
+// the protobuf wire types
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+type protobufCodec struct{}
+
+func (protobufCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	buf := []byte{}
+	if ev.Name != "" {
+		buf = appendProtoBytes(buf, protoName, []byte(ev.Name))
+	}
+	for _, arg := range ev.Args {
+		data, err := json.Marshal(arg)
+		if err != nil {
+			return nil, err
+		}
+		buf = appendProtoBytes(buf, protoArgs, data)
+	}
+
+	// sorted, so the same envelope is always encoded the same way
+	keys := make([]string, 0, len(ev.Headers))
+	for k := range ev.Headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry := appendProtoBytes(nil, protoMapKey, []byte(k))
+		entry = appendProtoBytes(entry, protoMapValue, []byte(ev.Headers[k]))
+		buf = appendProtoBytes(buf, protoHeaders, entry)
+	}
+	return buf, nil
+}
+
+func (protobufCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	ev := &Emitter.Event{Args: []interface{}{}}
+	err := readProto(data, func(field int, value []byte) error {
+		switch field {
+		case protoName:
+			ev.Name = string(value)
+		case protoArgs:
+			var arg interface{}
+			if err := json.Unmarshal(value, &arg); err != nil {
+				return err
+			}
+			ev.Args = append(ev.Args, arg)
+		case protoHeaders:
+			var k, v string
+			err := readProto(value, func(field int, value []byte) error {
+				switch field {
+				case protoMapKey:
+					k = string(value)
+				case protoMapValue:
+					v = string(value)
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			if ev.Headers == nil {
+				ev.Headers = map[string]string{}
+			}
+			ev.Headers[k] = v
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return ev, nil
+}
+
+// appendProtoBytes() - append a length delimited field
+func appendProtoBytes(buf []byte, field int, value []byte) []byte {
+	buf = appendVarint(buf, uint64(field)<<3|wireBytes)
+	buf = appendVarint(buf, uint64(len(value)))
+	return append(buf, value...)
+}
+
+func appendVarint(buf []byte, v uint64) []byte {
+	tmp := make([]byte, binary.MaxVarintLen64)
+	return append(buf, tmp[:binary.PutUvarint(tmp, v)]...)
+}
+
+// readProto() - walk the length delimited fields of the message, skipping the others
+func readProto(data []byte, fn func(field int, value []byte) error) error {
+	for len(data) > 0 {
+		tag, n := binary.Uvarint(data)
+		if n <= 0 {
+			return ErrProtobufInvalid
+		}
+		data = data[n:]
+
+		switch tag & 7 {
+		case wireVarint:
+			if _, n = binary.Uvarint(data); n <= 0 {
+				return ErrProtobufInvalid
+			}
+			data = data[n:]
+		case wireFixed64, wireFixed32:
+			size := 8
+			if tag&7 == wireFixed32 {
+				size = 4
+			}
+			if len(data) < size {
+				return ErrProtobufInvalid
+			}
+			data = data[size:]
+		case wireBytes:
+			length, n := binary.Uvarint(data)
+			if n <= 0 || length > uint64(len(data)-n) {
+				return ErrProtobufInvalid
+			}
+			value := data[n : n+int(length)]
+			data = data[n+int(length):]
+			if err := fn(int(tag>>3), value); err != nil {
+				return err
+			}
+		default:
+			return ErrProtobufInvalid
+		}
+	}
+	return nil
+}
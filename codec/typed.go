@@ -0,0 +1,116 @@
+package codec
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Schemas - the registry of the declared event args, i.e an *Emitter.Emitter, see Emitter.RegisterEvent
+type Schemas interface {
+	Schema(event string) (Emitter.Schema, bool)
+}
+
+// Typed() - the codec carrying the args declared in the schemas as their JSON form through the codec,
+// and decoding them back to their declared types, so the listeners of the bridged events receive
+// the typed payloads instead of maps or raw bytes; the undeclared events are left untouched.
+// The bridges use it over codec.JSON by default
+func Typed(schemas Schemas, codec Codec) Codec {
+	return typedCodec{schemas, codec}
+}
+
+type typedCodec struct {
+	schemas Schemas
+	codec   Codec
+}
+
+func (self typedCodec) Marshal(ev *Emitter.Event) ([]byte, error) {
+	schema, ok := self.schemas.Schema(ev.Name)
+	if !ok {
+		return self.codec.Marshal(ev)
+	}
+
+	encoded := *ev
+	encoded.Args = append([]interface{}{}, ev.Args...)
+	for i, typ := range schema.Args {
+		if i >= len(encoded.Args) || encoded.Args[i] == nil || !composite(typ) {
+			continue
+		}
+		data, err := json.Marshal(encoded.Args[i])
+		if err != nil {
+			return nil, fmt.Errorf("codec: %s arg %d: %v", ev.Name, i, err)
+		}
+		var generic interface{}
+		if err := json.Unmarshal(data, &generic); err != nil {
+			return nil, err
+		}
+		encoded.Args[i] = generic
+	}
+	return self.codec.Marshal(&encoded)
+}
+
+func (self typedCodec) Unmarshal(data []byte) (*Emitter.Event, error) {
+	ev, err := self.codec.Unmarshal(data)
+	if err != nil {
+		return nil, err
+	}
+	schema, ok := self.schemas.Schema(ev.Name)
+	if !ok {
+		return ev, nil
+	}
+
+	for i, typ := range schema.Args {
+		if i >= len(ev.Args) {
+			break
+		}
+		if ev.Args[i], err = decodeArg(ev.Args[i], typ); err != nil {
+			return nil, fmt.Errorf("codec: %s arg %d: %v", ev.Name, i, err)
+		}
+	}
+	return ev, nil
+}
+
+// decodeArg() - the arg as the declared type, decoded from its raw JSON or converted through its JSON form
+func decodeArg(arg interface{}, typ reflect.Type) (interface{}, error) {
+	if typ == nil || arg == nil || reflect.TypeOf(arg).AssignableTo(typ) {
+		return arg, nil
+	}
+
+	var data []byte
+	switch raw := arg.(type) {
+	case []byte:
+		data = raw
+	case json.RawMessage:
+		data = raw
+	default:
+		var err error
+		if data, err = json.Marshal(arg); err != nil {
+			return nil, err
+		}
+	}
+
+	value := reflect.New(typ)
+	if err := json.Unmarshal(data, value.Interface()); err != nil {
+		return nil, err
+	}
+	return value.Elem().Interface(), nil
+}
+
+// composite() - whether the args of the type are carried as their JSON form: structs, maps and slices but bytes
+func composite(typ reflect.Type) bool {
+	if typ == nil {
+		return false
+	}
+	if typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		return true
+	case reflect.Slice, reflect.Array:
+		return typ.Elem().Kind() != reflect.Uint8
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package codec
+
+import (
+	"reflect"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestTyped(t *testing.T) {
+	emitter := Emitter.New()
+	emitter.RegisterEvent("user.created", Emitter.Schema{Args: Emitter.ArgTypes(user{}, 0, &user{}), Variadic: true})
+
+	for name, c := range map[string]Codec{"json": JSON, "gob": Gob, "protobuf": Protobuf} {
+		typed := Typed(emitter, c)
+		ev := &Emitter.Event{Name: "user.created", Args: []interface{}{user{"john", 42}, 7, &user{"jane", 7}, "extra"}}
+		data, err := typed.Marshal(ev)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		got, err := typed.Unmarshal(data)
+		if err != nil {
+			t.Fatal(name, err)
+		}
+		if !reflect.DeepEqual(ev, got) {
+			t.Errorf("%s: Expected %#v - Got %#v", name, ev, got)
+		}
+	}
+
+	// the undeclared events and the raw JSON args
+	typed := Typed(emitter, JSON)
+	got, err := typed.Unmarshal([]byte(`{"name":"user.updated","args":[{"Name":"john"}]}`))
+	if err != nil || !reflect.DeepEqual(got.Args, []interface{}{map[string]interface{}{"Name": "john"}}) {
+		t.Errorf("Expected the undeclared args untouched - Got %#v (%v)", got, err)
+	}
+	arg, err := decodeArg([]byte(`{"Name":"john","Age":1}`), reflect.TypeOf(user{}))
+	if err != nil || arg != (user{"john", 1}) {
+		t.Errorf("Expected the raw JSON decoded - Got %#v (%v)", arg, err)
+	}
+	if _, err := typed.Unmarshal([]byte(`{"name":"user.created","args":["john"]}`)); err == nil {
+		t.Error("Expected the mismatching arg to fail")
+	}
+}
@@ -0,0 +1,156 @@
+package Emitter
+
+import (
+	"context"
+	"sync"
+)
+
+// Combination - the handle of a combinator listening on several events
+type Combination struct {
+	subscriptions []*Subscription
+}
+
+// Remove() - stop listening on the combined events
+func (self *Combination) Remove() {
+	for _, subscription := range self.subscriptions {
+		subscription.Remove()
+	}
+}
+
+// CombineLatest() - run the callback with the latest args of every event, in the order of events,
+// once all of them occurred and then again on each subsequent occurrence of any of them
+func (self *Emitter) CombineLatest(events []string, callback func(latest [][]interface{})) *Combination {
+	return combineLatest(self, events, callback)
+}
+
+// Zip() - run the callback with the args of the nth occurrence of every event, in the order
+// of events, as soon as all of them occurred n times; unpaired occurrences are queued
+func (self *Emitter) Zip(events []string, callback func(args [][]interface{})) *Combination {
+	return zip(self, events, callback)
+}
+
+func combineLatest(source EventSource, events []string, callback func([][]interface{})) *Combination {
+	mutex := &sync.Mutex{}
+	latest := make([][]interface{}, len(events))
+	occurred := make([]bool, len(events))
+	missing := len(events)
+
+	combination := &Combination{}
+	for i, event := range events {
+		i := i
+		combination.subscriptions = append(combination.subscriptions, source.OnEvent(event, func(ev *Event) {
+			mutex.Lock()
+			if !occurred[i] {
+				occurred[i] = true
+				missing--
+			}
+			latest[i] = ev.Args
+			if missing > 0 {
+				mutex.Unlock()
+				return
+			}
+			snapshot := append([][]interface{}{}, latest...)
+			mutex.Unlock()
+
+			callback(snapshot)
+		}))
+	}
+	return combination
+}
+
+func zip(source EventSource, events []string, callback func([][]interface{})) *Combination {
+	mutex := &sync.Mutex{}
+	queues := make([][][]interface{}, len(events))
+
+	combination := &Combination{}
+	for i, event := range events {
+		i := i
+		combination.subscriptions = append(combination.subscriptions, source.OnEvent(event, func(ev *Event) {
+			mutex.Lock()
+			queues[i] = append(queues[i], ev.Args)
+			for _, queue := range queues {
+				if len(queue) == 0 {
+					mutex.Unlock()
+					return
+				}
+			}
+			zipped := make([][]interface{}, len(queues))
+			for k := range queues {
+				zipped[k], queues[k] = queues[k][0], queues[k][1:]
+			}
+			mutex.Unlock()
+
+			callback(zipped)
+		}))
+	}
+	return combination
+}
+
+// WaitAll() - block until every event occurred, returning the args of their first occurrence
+// by event name, or until the context is done, returning its error; repeated events are waited once
+func (self *Emitter) WaitAll(ctx context.Context, events ...string) (map[string][]interface{}, error) {
+	mutex := &sync.Mutex{}
+	payloads := make(map[string][]interface{}, len(events))
+	done := make(chan struct{})
+
+	distinct := make(map[string]bool, len(events))
+	for _, event := range events {
+		distinct[event] = true
+	}
+
+	combination := &Combination{}
+	for event := range distinct {
+		event := event
+		combination.subscriptions = append(combination.subscriptions, self.OnEvent(event, func(ev *Event) {
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			if _, ok := payloads[event]; ok {
+				return
+			}
+			payloads[event] = ev.Args
+			if len(payloads) == len(distinct) {
+				close(done)
+			}
+		}))
+	}
+	defer combination.Remove()
+
+	if len(events) == 0 {
+		return payloads, nil
+	}
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	return payloads, nil
+}
+
+// WaitAny() - block until one of the events occurred, returning its name and args,
+// or until the context is done, returning its error
+func (self *Emitter) WaitAny(ctx context.Context, events ...string) (string, []interface{}, error) {
+	first := make(chan *Event, 1)
+
+	combination := &Combination{}
+	for _, event := range events {
+		combination.subscriptions = append(combination.subscriptions, self.OnEvent(event, func(ev *Event) {
+			select {
+			case first <- ev:
+			default:
+			}
+		}))
+	}
+	defer combination.Remove()
+
+	select {
+	case ev := <-first:
+		return ev.Name, ev.Args, nil
+	case <-ctx.Done():
+		return "", nil, ctx.Err()
+	}
+}
@@ -0,0 +1,102 @@
+package Emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestCombineLatest(t *testing.T) {
+	emitter := Construct()
+
+	calls := [][][]interface{}{}
+	combination := emitter.CombineLatest([]string{"cfg.loaded", "db.ready"}, func(latest [][]interface{}) {
+		calls = append(calls, latest)
+	})
+
+	emitter.EmitSync("cfg.loaded", "v1")
+	expect(t, 0, len(calls))
+
+	emitter.EmitSync("db.ready", "postgres")
+	emitter.EmitSync("cfg.loaded", "v2")
+
+	expect(t, 2, len(calls))
+	expect(t, "v1", calls[0][0][0])
+	expect(t, "postgres", calls[0][1][0])
+	expect(t, "v2", calls[1][0][0])
+
+	combination.Remove()
+	emitter.EmitSync("cfg.loaded", "v3")
+	expect(t, 2, len(calls))
+}
+
+func TestZip(t *testing.T) {
+	emitter := Construct()
+
+	pairs := [][][]interface{}{}
+	emitter.Zip([]string{"request", "response"}, func(args [][]interface{}) {
+		pairs = append(pairs, args)
+	})
+
+	emitter.EmitSync("request", 1)
+	emitter.EmitSync("request", 2)
+	emitter.EmitSync("response", "a")
+	emitter.EmitSync("response", "b")
+	emitter.EmitSync("response", "c")
+
+	expect(t, 2, len(pairs))
+	expect(t, 1, pairs[0][0][0])
+	expect(t, "a", pairs[0][1][0])
+	expect(t, 2, pairs[1][0][0])
+	expect(t, "b", pairs[1][1][0])
+}
+
+func TestWaitAll(t *testing.T) {
+	emitter := Construct()
+
+	go func() {
+		for emitter.ListenersCount("db.ready") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		emitter.EmitSync("cfg.loaded", "v1")
+		emitter.EmitSync("cfg.loaded", "v2")
+		emitter.EmitSync("db.ready", "postgres")
+	}()
+
+	payloads, err := emitter.WaitAll(context.Background(), "cfg.loaded", "db.ready")
+	expect(t, nil, err)
+	expect(t, "v1", payloads["cfg.loaded"][0])
+	expect(t, "postgres", payloads["db.ready"][0])
+	expect(t, 0, emitter.ListenersCount("db.ready"))
+
+	go func() {
+		for emitter.ListenersCount("cfg.loaded") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		emitter.EmitSync("cfg.loaded", "v3")
+	}()
+	payloads, err = emitter.WaitAll(context.Background(), "cfg.loaded", "cfg.loaded")
+	expect(t, nil, err, "repeated events are waited once")
+	expect(t, 1, len(payloads))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	_, err = emitter.WaitAll(ctx, "never")
+	expect(t, context.DeadlineExceeded, err)
+}
+
+func TestWaitAny(t *testing.T) {
+	emitter := Construct()
+
+	go func() {
+		for emitter.ListenersCount("failed") == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		emitter.EmitSync("failed", "boom")
+	}()
+
+	event, args, err := emitter.WaitAny(context.Background(), "done", "failed")
+	expect(t, nil, err)
+	expect(t, "failed", event)
+	expect(t, "boom", args[0])
+}
@@ -0,0 +1,85 @@
+package Emitter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// compiledPattern - a pattern parsed once into the literal parts around its stars
+type compiledPattern struct {
+	source  string
+	all     bool
+	literal bool
+	parts   []string
+	// params - the regexp of a pattern with named parameters
+	params *regexp.Regexp
+	// fn - the matching of the emitter's own dialect, if any
+	fn func(event string) bool
+}
+
+// compilePattern() - parse the pattern
+func compilePattern(pattern string) *compiledPattern {
+	params := paramPattern(pattern)
+	return &compiledPattern{
+		source:  pattern,
+		all:     pattern == "**",
+		literal: !strings.Contains(pattern, "*") && params == nil,
+		parts:   strings.Split(pattern, "*"),
+		params:  params,
+	}
+}
+
+// match() - whether the event name matches the pattern, as Match tells: the first part must be the
+// name's prefix, the last one its suffix, and the ones between are found leftmost, in their order
+func (self *compiledPattern) match(event string) bool {
+	if self.fn != nil {
+		return self.fn(event)
+	}
+	if self.params != nil {
+		return self.params.MatchString(event)
+	}
+	if self.all || self.literal {
+		return self.all || self.source == event
+	}
+
+	first, last := self.parts[0], self.parts[len(self.parts)-1]
+	if len(event) < len(first)+len(last) || !strings.HasPrefix(event, first) || !strings.HasSuffix(event, last) {
+		return false
+	}
+	rest := event[len(first) : len(event)-len(last)]
+	for _, part := range self.parts[1 : len(self.parts)-1] {
+		i := strings.Index(rest, part)
+		if i < 0 {
+			return false
+		}
+		rest = rest[i+len(part):]
+	}
+	return true
+}
+
+// compiled() - the compiled pattern, compiled on its first use; the entries of the patterns without
+// listeners anymore are dropped once they outnumber the others. The mutex must be held
+func (self *Emitter) compiled(pattern string) *compiledPattern {
+	if c, ok := self.patterns[pattern]; ok {
+		return c
+	}
+	if self.patterns == nil || len(self.patterns) > 2*len(self.listeners)+16 {
+		patterns := make(map[string]*compiledPattern, len(self.listeners))
+		for event := range self.listeners {
+			if c, ok := self.patterns[event.(string)]; ok {
+				patterns[c.source] = c
+			}
+		}
+		self.patterns = patterns
+	}
+
+	c := compilePattern(pattern)
+	if compiler, ok := self.matcher.(CompilingMatcher); ok {
+		c.fn = compiler.Compile(pattern)
+	} else if self.matcher != nil {
+		matcher := self.matcher
+		c.fn = func(event string) bool { return matcher.Match(pattern, event) }
+	}
+	self.patterns[pattern] = c
+	return c
+}
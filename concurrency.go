@@ -0,0 +1,125 @@
+package Emitter
+
+import "sync"
+
+// concurrencyLimit - the slots of the async listener invocations of the events matching the pattern
+type concurrencyLimit struct {
+	pattern string
+	slots   chan struct{}
+	// parked - the pool tasks waiting for a slot, resubmitted as the slots are freed
+	parked []func()
+	mutex  *sync.Mutex
+}
+
+// newConcurrencyLimit() - create a new limit of n slots
+func newConcurrencyLimit(pattern string, n int) *concurrencyLimit {
+	return &concurrencyLimit{pattern: pattern, slots: make(chan struct{}, n), mutex: &sync.Mutex{}}
+}
+
+// take() - take a slot without waiting, or park the retry to be called once one is freed
+func (self *concurrencyLimit) take(retry func()) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	select {
+	case self.slots <- struct{}{}:
+		return true
+	default:
+		self.parked = append(self.parked, retry)
+		return false
+	}
+}
+
+// release() - free a slot, resubmitting the oldest parked task
+func (self *concurrencyLimit) release() {
+	self.mutex.Lock()
+	<-self.slots
+	var retry func()
+	if len(self.parked) > 0 {
+		retry = self.parked[0]
+		self.parked = self.parked[1:]
+	}
+	self.mutex.Unlock()
+
+	if retry != nil {
+		retry()
+	}
+}
+
+// SetConcurrency() - limit how many listener invocations of the events matching the pattern run
+// simultaneously in asynchronous mode, the others wait for a slot without holding a goroutine of the
+// pool; it protects the resource heavy listeners (i.e DB writers) from bursts, n <= 0 removes the limit.
+// The invocations of an event matching several limited patterns take a slot of each.
+func (self *Emitter) SetConcurrency(pattern string, n int) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	limits := self.limits[:0:0]
+	for _, limit := range self.limits {
+		if limit.pattern != pattern {
+			limits = append(limits, limit)
+		}
+	}
+	if n > 0 {
+		limits = append(limits, newConcurrencyLimit(pattern, n))
+	}
+	self.limits = limits
+	return self
+}
+
+// limitsOf() - the limits of the event's async listener invocations
+func (self *Emitter) limitsOf(event string) []*concurrencyLimit {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if len(self.limits) == 0 {
+		return nil
+	}
+	event = self.key(event)
+	limits := []*concurrencyLimit{}
+	for _, limit := range self.limits {
+		if self.compiled(limit.pattern).match(event) {
+			limits = append(limits, limit)
+		}
+	}
+	return limits
+}
+
+// callLimited() - invoke the listener once it has a slot of each limit, always taken in the same order
+func (self Listener) callLimited(ev *Event, limits []*concurrencyLimit) {
+	for _, limit := range limits {
+		limit.slots <- struct{}{}
+	}
+	defer release(limits)
+	self.call(ev)
+}
+
+// submitLimited() - run the task in the emitter's pool once it has a slot of each limit: missing one,
+// the task frees its worker and is parked, to be resubmitted once a slot is freed
+func (self *Emitter) submitLimited(task func(), limits []*concurrencyLimit, priority int) {
+	if len(limits) == 0 {
+		self.submit(task, priority)
+		return
+	}
+	var attempt func()
+	retry := func() { self.submit(attempt, priority) }
+	attempt = func() {
+		for i, limit := range limits {
+			if !limit.take(retry) {
+				release(limits[:i])
+				return
+			}
+		}
+		defer release(limits)
+		task()
+	}
+	self.submit(attempt, priority)
+}
+
+// release() - free the slots of the limits
+func release(limits []*concurrencyLimit) {
+	for _, limit := range limits {
+		limit.release()
+	}
+}
@@ -0,0 +1,64 @@
+package Emitter
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetConcurrency(t *testing.T) {
+	emitter := Construct().SetConcurrency("db.*", 2)
+
+	var running, max int32
+	wg := sync.WaitGroup{}
+	emitter.On("db.write", func(args ...interface{}) {
+		defer wg.Done()
+		n := atomic.AddInt32(&running, 1)
+		for m := atomic.LoadInt32(&max); n > m && !atomic.CompareAndSwapInt32(&max, m, n); m = atomic.LoadInt32(&max) {
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	})
+
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		emitter.EmitAsync("db.write", nil)
+	}
+	wg.Wait()
+	expect(t, int32(2), atomic.LoadInt32(&max))
+
+	emitter.SetConcurrency("db.*", 0)
+	expect(t, 0, len(emitter.limitsOf("db.write")))
+}
+
+func TestConcurrencyPool(t *testing.T) {
+	pool := NewPool(2)
+	defer pool.Close()
+	emitter := New(WithPool(pool)).SetConcurrency("db.*", 1)
+
+	release := make(chan bool)
+	writes := int32(0)
+	emitter.On("db.write", func(args ...interface{}) {
+		atomic.AddInt32(&writes, 1)
+		<-release
+	})
+	other := make(chan bool)
+	emitter.On("mail.send", func(args ...interface{}) { close(other) })
+
+	emitter.EmitAsync("db.write", nil)
+	emitter.EmitAsync("db.write", nil)
+	emitter.EmitAsync("db.write", nil)
+	emitter.EmitAsync("mail.send", nil)
+	select {
+	case <-other:
+	case <-time.After(time.Second):
+		t.Fatal("the invocations waiting for a slot hold the pool")
+	}
+	expect(t, int32(1), atomic.LoadInt32(&writes))
+
+	close(release)
+	for atomic.LoadInt32(&writes) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+}
@@ -0,0 +1,71 @@
+package Emitter
+
+import (
+	"fmt"
+)
+
+// SubscriptionConfig - a subscription declared in a Config
+type SubscriptionConfig struct {
+	// Event - the event (pattern) the handler listens on
+	Event string `json:"event" yaml:"event"`
+	// Handler - the name of the handler, see RegisterHandler
+	Handler string `json:"handler" yaml:"handler"`
+	Once    bool   `json:"once,omitempty" yaml:"once,omitempty"`
+	// Group - the group of the listener, see Subscription.Group
+	Group string `json:"group,omitempty" yaml:"group,omitempty"`
+	// Execution - "inline" (the default), "async" or "pool", see RunAs
+	Execution string `json:"execution,omitempty" yaml:"execution,omitempty"`
+}
+
+// Config - the event topology of an emitter, to keep it in JSON or YAML files diffed across environments
+type Config struct {
+	Subscriptions []SubscriptionConfig `json:"subscriptions" yaml:"subscriptions"`
+}
+
+// executions - the Execution of the names of the configs
+var executions = map[string]Execution{"": ExecutionInline, "inline": ExecutionInline, "async": ExecutionAsync, "pool": ExecutionPool}
+
+// RegisterHandler() - name the handler, for the subscriptions of the configs to refer to it
+func (self *Emitter) RegisterHandler(name string, handler func(...interface{})) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.handlers == nil {
+		self.handlers = make(map[string]func(...interface{}))
+	}
+	self.handlers[name] = handler
+	return self
+}
+
+// ApplyConfig() - register the subscriptions of the config, all of them or none if one refers to an unknown
+// handler or execution or is rejected (reserved event, frozen emitter...), the ones registered before it are
+// removed then; the returned subscriptions remove them, i.e before applying a new config
+func (self *Emitter) ApplyConfig(config Config) (Subscriptions, error) {
+	self.mutex.Lock()
+	listeners := make([]Listener, len(config.Subscriptions))
+	for i, s := range config.Subscriptions {
+		handler, ok := self.handlers[s.Handler]
+		if !ok {
+			self.mutex.Unlock()
+			return nil, fmt.Errorf("emitter: subscription %d on %s: unknown handler %q", i, s.Event, s.Handler)
+		}
+		execution, ok := executions[s.Execution]
+		if !ok {
+			self.mutex.Unlock()
+			return nil, fmt.Errorf("emitter: subscription %d on %s: unknown execution %q", i, s.Event, s.Execution)
+		}
+		listeners[i] = Listener{callback: handler, once: s.Once, group: s.Group, execution: execution}
+	}
+	self.mutex.Unlock()
+
+	subscriptions := make(Subscriptions, 0, len(listeners))
+	for i, l := range listeners {
+		subscription := self.addListenerInternal(config.Subscriptions[i].Event, l)
+		if subscription.ID() == 0 {
+			subscriptions.Remove()
+			return nil, fmt.Errorf("emitter: subscription %d on %s: rejected", i, config.Subscriptions[i].Event)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+	return subscriptions, nil
+}
@@ -0,0 +1,64 @@
+package Emitter
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestApplyConfig(t *testing.T) {
+	var config Config
+	err := json.Unmarshal([]byte(`{"subscriptions": [
+		{"event": "user.*", "handler": "audit", "group": "audit"},
+		{"event": "user.created", "handler": "mailer", "once": true}
+	]}`), &config)
+	expect(t, nil, err)
+
+	emitter := New()
+	audited, mailed := 0, 0
+	emitter.RegisterHandler("audit", func(args ...interface{}) { audited++ })
+	emitter.RegisterHandler("mailer", func(args ...interface{}) { mailed++ })
+
+	subs, err := emitter.ApplyConfig(config)
+	expect(t, nil, err)
+	expect(t, 2, len(subs))
+
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("user.created")
+	expect(t, 2, audited)
+	expect(t, 1, mailed, "the once subscription is removed after its first run")
+
+	emitter.EmitWith("user.updated", nil, OnlyGroup("audit"))
+	expect(t, 3, audited, "the group of the config is applied")
+
+	subs.Remove()
+	emitter.EmitSync("user.created")
+	expect(t, 3, audited, "the subscriptions remove the wiring")
+}
+
+func TestApplyConfigUnknown(t *testing.T) {
+	emitter := New()
+	emitter.RegisterHandler("audit", func(args ...interface{}) {})
+
+	_, err := emitter.ApplyConfig(Config{Subscriptions: []SubscriptionConfig{
+		{Event: "user.*", Handler: "audit"},
+		{Event: "user.created", Handler: "mailer"},
+	}})
+	expect(t, true, err != nil, "an unknown handler is an error")
+	expect(t, 0, len(emitter.Listeners("user.created")), "nothing is registered")
+
+	_, err = emitter.ApplyConfig(Config{Subscriptions: []SubscriptionConfig{{Event: "user.*", Handler: "audit", Execution: "threads"}}})
+	expect(t, true, err != nil, "an unknown execution is an error")
+
+	emitter.Reserve("system.*")
+	_, err = emitter.ApplyConfig(Config{Subscriptions: []SubscriptionConfig{
+		{Event: "user.*", Handler: "audit"},
+		{Event: "system.halt", Handler: "audit"},
+	}})
+	expect(t, true, err != nil, "a rejected subscription is an error")
+	expect(t, 0, len(emitter.Listeners("user.created")), "the ones registered before it are removed")
+
+	emitter.RegisterHandler("mailer", func(args ...interface{}) {})
+	clone := emitter.Clone()
+	_, err = clone.ApplyConfig(Config{Subscriptions: []SubscriptionConfig{{Event: "user.created", Handler: "mailer", Execution: "async"}}})
+	expect(t, nil, err, "the clone keeps the handlers")
+}
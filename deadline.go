@@ -0,0 +1,75 @@
+package Emitter
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Context() - the context of the emit, context.Background() if it has none, see EmitContext
+func (self *Event) Context() context.Context {
+	if self.ctx == nil {
+		return context.Background()
+	}
+	return self.ctx
+}
+
+// WithContext() - a copy of the event emitted under the context, i.e for EmitEvent
+func (self *Event) WithContext(ctx context.Context) *Event {
+	out := *self
+	out.ctx = ctx
+	return &out
+}
+
+// EmitContext() - run all listeners of the event in synchronous mode, under the context: the listeners
+// registered with OnContext receive a context derived from it
+func (self *Emitter) EmitContext(ctx context.Context, event string, args ...interface{}) *Emitter {
+	return self.EmitEvent(&Event{Name: event, Args: args, ctx: ctx})
+}
+
+// OnContext() - register a new listener receiving a context derived from the emit's one, cancelled once
+// it returns; it isn't called once the emit's context is done
+func (self *Emitter) OnContext(event string, callback func(context.Context, ...interface{})) *Subscription {
+	return self.OnContextTimeout(event, 0, callback)
+}
+
+// OnContextTimeout() - register a new listener receiving a context derived from the emit's one with a
+// slice of its deadline: the context times out after the slice even if the emit's doesn't, so one slow
+// listener can't consume the whole budget of the emit, a slice <= 0 doesn't limit it
+func (self *Emitter) OnContextTimeout(event string, slice time.Duration, callback func(context.Context, ...interface{})) *Subscription {
+	return self.addListenerInternal(event, Listener{
+		handler: contextHandler(slice, func(ctx context.Context, args ...interface{}) error {
+			callback(ctx, args...)
+			return nil
+		}),
+		origin: reflect.ValueOf(callback).Pointer(),
+	})
+}
+
+// OnContextErr() - register a new listener receiving a context derived from the emit's one, as OnContext
+// does, and failing with its error: the first one fails the EmitAsyncWait, and the errors are listed
+// by the EmitAsyncResult, they are dropped by the other emits
+func (self *Emitter) OnContextErr(event string, callback func(context.Context, ...interface{}) error) *Subscription {
+	return self.addListenerInternal(event, Listener{handler: contextHandler(0, callback), origin: reflect.ValueOf(callback).Pointer()})
+}
+
+// contextHandler() - the handler calling the callback with its context and reporting its error to the emit
+func contextHandler(slice time.Duration, callback func(context.Context, ...interface{}) error) func(*Event) {
+	return func(ev *Event) {
+		var ctx context.Context
+		var cancel context.CancelFunc
+		if slice > 0 {
+			ctx, cancel = context.WithTimeout(ev.Context(), slice)
+		} else {
+			ctx, cancel = context.WithCancel(ev.Context())
+		}
+		defer cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		if err := callback(ctx, ev.Args...); err != nil && ev.failed != nil {
+			ev.failed(err)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+package Emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestOnContext(t *testing.T) {
+	emitter := New()
+	type key struct{}
+	deadlines := []time.Time{}
+	values := []interface{}{}
+	emitter.OnContext("user.created", func(ctx context.Context, args ...interface{}) {
+		deadline, _ := ctx.Deadline()
+		deadlines = append(deadlines, deadline)
+		values = append(values, ctx.Value(key{}))
+	})
+	emitter.OnContextTimeout("user.created", 10*time.Millisecond, func(ctx context.Context, args ...interface{}) {
+		<-ctx.Done()
+		deadline, _ := ctx.Deadline()
+		deadlines = append(deadlines, deadline)
+		expect(t, context.DeadlineExceeded, ctx.Err())
+	})
+
+	parent, cancel := context.WithTimeout(context.WithValue(context.Background(), key{}, "v"), time.Minute)
+	defer cancel()
+	start := time.Now()
+	emitter.EmitContext(parent, "user.created")
+	parentDeadline, _ := parent.Deadline()
+	expect(t, 2, len(deadlines))
+	expect(t, parentDeadline, deadlines[0], "the listener gets the emit's deadline")
+	expect(t, "v", values[0])
+	expect(t, true, deadlines[1].Before(parentDeadline), "the slice shortens the deadline")
+	expect(t, true, time.Since(start) < time.Second, "the slow listener doesn't take the emit's budget")
+
+	emitter.EmitSync("user.created")
+	expect(t, true, deadlines[2].IsZero(), "the emits without context don't have a deadline")
+
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	emitter.EmitEvent((&Event{Name: "user.created"}).WithContext(done))
+	expect(t, 4, len(deadlines), "the listeners aren't called once the emit's context is done")
+}
+
+func TestOnContextTenant(t *testing.T) {
+	acme := New().Tenant("acme")
+	calls := 0
+	acme.OnContext("user.created", func(ctx context.Context, args ...interface{}) { calls++ })
+
+	done, stop := context.WithCancel(context.Background())
+	stop()
+	acme.EmitContext(done, "user.created")
+	expect(t, 0, calls, "the tenant's event keeps the emit's context")
+}
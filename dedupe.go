@@ -0,0 +1,38 @@
+package Emitter
+
+// DedupeListeners() - run a function registered on several matching patterns once per emit,
+// i.e a cross-cutting listener on both "user.*" and "**", the first registration is the one run;
+// the functions are told apart as RemoveListener does, the closures of the same code being the same one
+func (self *Emitter) DedupeListeners(dedupe bool) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.dedupe = dedupe
+	self.invalidate()
+	return self
+}
+
+// Dedupe() - run the subscribed listener once per emit even if its function is registered
+// on other matching patterns, as DedupeListeners does for all the listeners
+func (self *Subscription) Dedupe() *Subscription {
+	return self.update(func(l *Listener) { l.dedupe = true })
+}
+
+// dedupeListeners() - drop the later listeners whose function already runs, the mutex must be held
+func (self *Emitter) dedupeListeners(listeners []Listener) []Listener {
+	var seen map[uintptr]bool
+	deduped := listeners[:0]
+	for _, l := range listeners {
+		if self.dedupe || l.dedupe {
+			if seen == nil {
+				seen = map[uintptr]bool{}
+			}
+			if seen[l.pointer()] {
+				continue
+			}
+			seen[l.pointer()] = true
+		}
+		deduped = append(deduped, l)
+	}
+	return deduped
+}
@@ -0,0 +1,43 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestDedupeListeners(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	callback := func(args ...interface{}) { counter++ }
+	closure := func() func(...interface{}) {
+		return func(args ...interface{}) { counter += 10 }
+	}
+
+	emitter.On("user.*", callback)
+	emitter.On("**", callback)
+	emitter.On("user.*", closure())
+	emitter.On("**", closure())
+
+	counter = 0
+	emitter.EmitSync("user.created")
+	expect(t, 22, counter)
+
+	counter = 0
+	emitter.DedupeListeners(true)
+	emitter.EmitSync("user.created")
+	expect(t, 11, counter, "the closures sharing their code are the same function")
+}
+
+func TestSubscriptionDedupe(t *testing.T) {
+	emitter := New()
+
+	counter := 0
+	callback := func(args ...interface{}) { counter++ }
+	emitter.Subscribe("user.*", callback).Dedupe()
+	emitter.Subscribe("**", callback).Dedupe()
+	emitter.Subscribe("user.created", func(args ...interface{}) { counter += 10 })
+
+	counter = 0
+	emitter.EmitSync("user.created")
+	expect(t, 11, counter, "the deduped function runs once per emit")
+}
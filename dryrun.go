@@ -0,0 +1,45 @@
+package Emitter
+
+// ListenerInfo - a listener an emit would run, see DryRun
+type ListenerInfo struct {
+	ID SubscriptionID
+	// Pattern - the event (pattern) the listener is registered on
+	Pattern string
+	// Params - the values of the named parameters of the pattern, see Params
+	Params map[string]string
+	Once   bool
+	Group  string
+	// Site - the call site of the registration, recorded once DetectLeaks is called
+	Site string
+}
+
+// DryRun() - the listeners the emit of the event would run, in their order, without running them: none if
+// the emit would be rejected as reserved or unauthorized, or by its schema or validators; the sampling
+// and the mutes aren't applied. To check the routing in the tests and the admin tools
+func (self *Emitter) DryRun(event string, args ...interface{}) []ListenerInfo {
+	if self.checkName(event, true) != nil || self.validatorError(event, args) != nil {
+		return nil
+	}
+	if err := self.schemaError(event, args); err != nil {
+		self.mutex.Lock()
+		mode := self.schemaMode
+		self.mutex.Unlock()
+		if mode == SchemaReject {
+			return nil
+		}
+	}
+
+	listeners := self.listenersOf(event)
+	infos := make([]ListenerInfo, 0, len(listeners))
+	for _, l := range listeners {
+		infos = append(infos, ListenerInfo{
+			ID:      l.ID(),
+			Pattern: l.event,
+			Params:  paramsOf(l.params, event),
+			Once:    l.once,
+			Group:   l.group,
+			Site:    l.site,
+		})
+	}
+	return infos
+}
@@ -0,0 +1,33 @@
+package Emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDryRun(t *testing.T) {
+	emitter := New()
+	ran := 0
+	exact := emitter.Subscribe("user.created", func(args ...interface{}) { ran++ })
+	wildcard := emitter.SubscribeOnce("user.*", func(args ...interface{}) { ran++ })
+	params := emitter.OnEvent("user.{action}", func(ev *Event) { ran++ })
+	emitter.On("order.*", func(args ...interface{}) { ran++ })
+
+	infos := emitter.DryRun("user.created", 42)
+	expect(t, 0, ran, "the listeners don't run")
+	expect(t, 3, len(infos))
+	expect(t, exact.ID(), infos[0].ID)
+	expect(t, "user.created", infos[0].Pattern)
+	expect(t, wildcard.ID(), infos[1].ID)
+	expect(t, true, infos[1].Once)
+	expect(t, params.ID(), infos[2].ID)
+	expect(t, "created", infos[2].Params["action"])
+
+	emitter.EmitSync("user.created")
+	expect(t, 2, len(emitter.DryRun("user.created")), "the one-time listener ran")
+
+	emitter.SetValidator("user.*", func(args []interface{}) error { return errors.New("invalid") })
+	expect(t, 0, len(emitter.DryRun("user.created")), "the rejected emits run nothing")
+	emitter.Reserve("order.*")
+	expect(t, 0, len(emitter.DryRun("order.created")))
+}
@@ -0,0 +1,49 @@
+package Emitter
+
+import (
+	"sort"
+)
+
+// EmitPattern() - run, in synchronous mode, the listeners registered on an exact name (without `*`)
+// that the pattern matches, each one with its own name, i.e "user.*" reaches the listeners of
+// "user.created" and "user.removed" but not the ones of "user.*" nor "**": a fan-out to a family of
+// concrete events. The names are emitted one after the other, in their order
+func (self *Emitter) EmitPattern(pattern string, args ...interface{}) *Emitter {
+	for _, name := range self.namesMatching(pattern) {
+		name := name
+		self.dispatchWith(&Event{Name: name, Args: args}, false, func(ev *Event, async bool) int {
+			if !self.admit(ev) {
+				return -1
+			}
+
+			self.mutex.Lock()
+			listeners := make([]Listener, 0, len(self.listeners[name]))
+			for _, l := range self.listeners[name] {
+				if !l.excepts(self, name) {
+					listeners = append(listeners, l)
+				}
+			}
+			self.mutex.Unlock()
+
+			return self.run(ev, listeners, false)
+		})
+	}
+	return self
+}
+
+// namesMatching() - the exact names with listeners that the pattern matches, sorted
+func (self *Emitter) namesMatching(pattern string) []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	compiled := self.compiled(self.key(pattern))
+	names := []string{}
+	for key, lis := range self.listeners {
+		name := key.(string)
+		if len(lis) > 0 && !isPattern(name) && !IsMetaEvent(name) && compiled.match(name) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
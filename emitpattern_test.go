@@ -0,0 +1,30 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestEmitPattern(t *testing.T) {
+	emitter := New()
+	received := []string{}
+	record := func(ev *Event) { received = append(received, ev.Name+":"+ev.Args[0].(string)) }
+	emitter.OnEvent("user.removed", record)
+	emitter.OnEvent("user.created", record)
+	emitter.OnEvent("user.*", record)
+	emitter.OnEvent("order.created", record)
+	emitter.Once("user.created", func(args ...interface{}) { received = append(received, "once") })
+
+	emitter.EmitPattern("user.*", "x")
+	expect(t, 3, len(received), "the exact listeners only")
+	expect(t, "user.created:x", received[0])
+	expect(t, "once", received[1])
+	expect(t, "user.removed:x", received[2])
+
+	received = received[:0]
+	emitter.EmitPattern("user.*", "y")
+	expect(t, 2, len(received), "the one-time listener ran")
+
+	received = received[:0]
+	emitter.EmitPattern("invoice.*", "z")
+	expect(t, 0, len(received))
+}
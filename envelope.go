@@ -0,0 +1,83 @@
+package Emitter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrTooManyArgs - the emit has more args than the emitter's EnvelopeLimits allow
+var ErrTooManyArgs = errors.New("emitter: too many args")
+
+// ErrPayloadTooLarge - the args of the emit are larger than the emitter's EnvelopeLimits allow
+var ErrPayloadTooLarge = errors.New("emitter: payload too large")
+
+// EnvelopeLimits - the bounds of the emits, protecting the bridges and the history buffers from
+// pathological payloads; a zero bound isn't checked
+type EnvelopeLimits struct {
+	// MaxArgs - the args an emit may have
+	MaxArgs int
+	// MaxBytes - the size the args may reach, measured on their JSON form (the length of strings and bytes)
+	MaxBytes int
+	// Truncate - drop the last args of the emits exceeding the limits instead of rejecting them
+	Truncate bool
+}
+
+// WithEnvelopeLimits() - check the emits against the limits: the ones exceeding them raise a
+// "limitExceeded" meta-event, with the event name and ErrTooManyArgs or ErrPayloadTooLarge, and are
+// rejected, or delivered with their last args dropped until they fit if the limits Truncate
+func WithEnvelopeLimits(limits EnvelopeLimits) Option {
+	return func(e *Emitter) {
+		e.envelope = limits
+	}
+}
+
+// bounded() - whether the emit fits the envelope limits, once truncated if they allow it
+func (self *Emitter) bounded(ev *Event) bool {
+	limits := self.envelope
+	if (limits.MaxArgs <= 0 && limits.MaxBytes <= 0) || IsMetaEvent(ev.Name) {
+		return true
+	}
+
+	var err error
+	n := len(ev.Args)
+	if limits.MaxArgs > 0 && n > limits.MaxArgs {
+		err = ErrTooManyArgs
+		n = limits.MaxArgs
+	}
+	if limits.MaxBytes > 0 {
+		size := 0
+		for i, arg := range ev.Args[:n] {
+			if size += argSize(arg); size > limits.MaxBytes {
+				err = ErrPayloadTooLarge
+				n = i
+				break
+			}
+		}
+	}
+	if err == nil {
+		return true
+	}
+
+	self.emitMeta("limitExceeded", ev.Name, err)
+	if !limits.Truncate {
+		return false
+	}
+	ev.Args = ev.Args[:n:n]
+	return true
+}
+
+// argSize() - the size of the arg in the envelope
+func argSize(arg interface{}) int {
+	switch v := arg.(type) {
+	case string:
+		return len(v)
+	case []byte:
+		return len(v)
+	}
+	data, err := json.Marshal(arg)
+	if err != nil {
+		return len(fmt.Sprint(arg))
+	}
+	return len(data)
+}
@@ -0,0 +1,41 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEnvelopeLimits(t *testing.T) {
+	emitter := New(WithEnvelopeLimits(EnvelopeLimits{MaxArgs: 2, MaxBytes: 10}))
+	delivered := 0
+	exceeded := []error{}
+	emitter.On("user.created", func(args ...interface{}) { delivered++ })
+	emitter.On("limitExceeded", func(args ...interface{}) { exceeded = append(exceeded, args[1].(error)) })
+
+	emitter.EmitSync("user.created", "john", 42)
+	emitter.EmitSync("user.created", 1, 2, 3)
+	emitter.EmitSync("user.created", strings.Repeat("a", 11))
+	emitter.EmitSync("user.created", map[string]string{"name": "john"})
+	expect(t, 1, delivered, "the emits exceeding the limits are rejected")
+	expect(t, 3, len(exceeded))
+	expect(t, ErrTooManyArgs, exceeded[0])
+	expect(t, ErrPayloadTooLarge, exceeded[1])
+	expect(t, ErrPayloadTooLarge, exceeded[2])
+}
+
+func TestEnvelopeLimitsTruncate(t *testing.T) {
+	emitter := New(WithEnvelopeLimits(EnvelopeLimits{MaxArgs: 2, MaxBytes: 10, Truncate: true}))
+	got := [][]interface{}{}
+	emitter.On("user.created", func(args ...interface{}) { got = append(got, args) })
+
+	args := []interface{}{"john", 42, true}
+	emitter.EmitSync("user.created", args...)
+	emitter.EmitSync("user.created", "john", "doe smith")
+	expect(t, 2, len(got), "the truncated emits are delivered")
+	expect(t, 2, len(got[0]))
+	expect(t, 1, len(got[1]))
+	expect(t, 3, len(args), "the args of the caller aren't touched")
+
+	emitter.EmitSync("user.created", strings.Repeat("a", 11))
+	expect(t, 0, len(got[2]), "an emit exceeding the limits with its first arg is emptied")
+}
@@ -0,0 +1,225 @@
+// Package eventbus adapts an emitter to the interface of asaskevich/EventBus,
+// so the projects built on it can migrate without rewriting their call sites:
+//
+//	bus := eventbus.New(Emitter.Construct())
+//	bus.Subscribe("main:calculator", func(a int, b int) { fmt.Println(a + b) })
+//	bus.Publish("main:calculator", 20, 40)
+//
+// The handlers are any functions, called with the published args as their
+// params, nil args being passed as the zero value of their param. Unlike
+// EventBus, the topics may be emitter patterns, i.e "main:*".
+package eventbus
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// BusSubscriber - the subscription methods of EventBus
+type BusSubscriber interface {
+	Subscribe(topic string, fn interface{}) error
+	SubscribeAsync(topic string, fn interface{}, transactional bool) error
+	SubscribeOnce(topic string, fn interface{}) error
+	SubscribeOnceAsync(topic string, fn interface{}) error
+	Unsubscribe(topic string, handler interface{}) error
+}
+
+// BusPublisher - the publishing method of EventBus
+type BusPublisher interface {
+	Publish(topic string, args ...interface{})
+}
+
+// BusController - the inspection and synchronization methods of EventBus
+type BusController interface {
+	HasCallback(topic string) bool
+	WaitAsync()
+}
+
+// EventBus - the interface of asaskevich/EventBus
+type EventBus interface {
+	BusController
+	BusSubscriber
+	BusPublisher
+}
+
+// Bus - an EventBus publishing and subscribing through an emitter
+type Bus struct {
+	emitter  *Emitter.Emitter
+	handlers map[string][]*handler
+	wg       sync.WaitGroup
+	mutex    *sync.Mutex
+}
+
+// handler - a subscribed function and how it's called
+type handler struct {
+	fn            reflect.Value
+	once          bool
+	async         bool
+	transactional bool
+	subscription  *Emitter.Subscription
+	// lock - serializes the calls of a transactional handler
+	lock *sync.Mutex
+}
+
+var _ EventBus = (*Bus)(nil)
+
+// New() - create a new bus on top of the emitter
+func New(e *Emitter.Emitter) *Bus {
+	return &Bus{
+		emitter:  e,
+		handlers: make(map[string][]*handler),
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// Emitter() - the emitter the bus publishes and subscribes through
+func (self *Bus) Emitter() *Emitter.Emitter {
+	return self.emitter
+}
+
+// Subscribe() - call the handler synchronously on every publish of the topic
+func (self *Bus) Subscribe(topic string, fn interface{}) error {
+	return self.subscribe(topic, fn, &handler{})
+}
+
+// SubscribeAsync() - call the handler in its own goroutine on every publish of the topic,
+// a transactional handler runs one call at a time, in the order of the publishes
+func (self *Bus) SubscribeAsync(topic string, fn interface{}, transactional bool) error {
+	return self.subscribe(topic, fn, &handler{async: true, transactional: transactional})
+}
+
+// SubscribeOnce() - call the handler synchronously on the next publish of the topic only
+func (self *Bus) SubscribeOnce(topic string, fn interface{}) error {
+	return self.subscribe(topic, fn, &handler{once: true})
+}
+
+// SubscribeOnceAsync() - call the handler in its own goroutine on the next publish of the topic only
+func (self *Bus) SubscribeOnceAsync(topic string, fn interface{}) error {
+	return self.subscribe(topic, fn, &handler{once: true, async: true})
+}
+
+// Unsubscribe() - remove the first subscription of the handler on the topic
+func (self *Bus) Unsubscribe(topic string, fn interface{}) error {
+	value := reflect.ValueOf(fn)
+
+	self.mutex.Lock()
+	handlers, ok := self.handlers[topic]
+	if !ok || len(handlers) == 0 {
+		self.mutex.Unlock()
+		return fmt.Errorf("topic %s doesn't exist", topic)
+	}
+	var removed *handler
+	for i, h := range handlers {
+		if h.fn.Type() == value.Type() && h.fn.Pointer() == value.Pointer() {
+			removed = h
+			self.handlers[topic] = append(handlers[:i:i], handlers[i+1:]...)
+			break
+		}
+	}
+	self.mutex.Unlock()
+
+	if removed != nil {
+		removed.subscription.Remove()
+	}
+	return nil
+}
+
+// Publish() - call the handlers subscribed on the topic with the args
+func (self *Bus) Publish(topic string, args ...interface{}) {
+	self.emitter.EmitSync(topic, args...)
+}
+
+// HasCallback() - whether any handler is subscribed on the topic
+func (self *Bus) HasCallback(topic string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	return len(self.handlers[topic]) > 0
+}
+
+// WaitAsync() - block until the async handlers running complete
+func (self *Bus) WaitAsync() {
+	self.wg.Wait()
+}
+
+// subscribe() - register the handler of fn on the topic
+func (self *Bus) subscribe(topic string, fn interface{}, h *handler) error {
+	h.fn = reflect.ValueOf(fn)
+	if h.fn.Kind() != reflect.Func {
+		return fmt.Errorf("%s is not of type reflect.Func", h.fn.Kind())
+	}
+	if h.transactional {
+		h.lock = &sync.Mutex{}
+	}
+
+	self.mutex.Lock()
+	self.handlers[topic] = append(self.handlers[topic], h)
+	self.mutex.Unlock()
+
+	h.subscription = self.emitter.OnEvent(topic, func(ev *Emitter.Event) {
+		self.deliver(topic, h, ev.Args)
+	})
+	return nil
+}
+
+// deliver() - call the handler with the published args, removing it first if it runs once
+func (self *Bus) deliver(topic string, h *handler, args []interface{}) {
+	if h.once && !self.claim(topic, h) {
+		return
+	}
+	if !h.async {
+		h.call(args)
+		return
+	}
+
+	self.wg.Add(1)
+	if h.transactional {
+		h.lock.Lock()
+	}
+	go func() {
+		defer self.wg.Done()
+		if h.transactional {
+			defer h.lock.Unlock()
+		}
+		h.call(args)
+	}()
+}
+
+// claim() - unsubscribe the one-time handler, false if it's already unsubscribed
+func (self *Bus) claim(topic string, h *handler) bool {
+	self.mutex.Lock()
+	claimed := false
+	handlers := self.handlers[topic]
+	for i, v := range handlers {
+		if v == h {
+			self.handlers[topic] = append(handlers[:i:i], handlers[i+1:]...)
+			claimed = true
+			break
+		}
+	}
+	self.mutex.Unlock()
+
+	if claimed {
+		h.subscription.Remove()
+	}
+	return claimed
+}
+
+// call() - call the handler's function, the nil args being passed as the zero value of their param
+func (self *handler) call(args []interface{}) {
+	typ := self.fn.Type()
+	in := make([]reflect.Value, len(args))
+	for i, arg := range args {
+		if arg != nil {
+			in[i] = reflect.ValueOf(arg)
+		} else if typ.IsVariadic() && i >= typ.NumIn()-1 {
+			in[i] = reflect.Zero(typ.In(typ.NumIn() - 1).Elem())
+		} else {
+			in[i] = reflect.Zero(typ.In(i))
+		}
+	}
+	self.fn.Call(in)
+}
@@ -0,0 +1,70 @@
+package eventbus
+
+import (
+	"sync/atomic"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestSubscribePublish(t *testing.T) {
+	bus := New(Emitter.Construct())
+
+	sum := 0
+	add := func(a int, b int) { sum += a + b }
+	expect(t, nil, bus.Subscribe("calc", add))
+	expect(t, true, bus.HasCallback("calc"))
+
+	bus.Publish("calc", 20, 40)
+	expect(t, 60, sum)
+
+	expect(t, nil, bus.Unsubscribe("calc", add))
+	expect(t, false, bus.HasCallback("calc"))
+	bus.Publish("calc", 1, 1)
+	expect(t, 60, sum)
+
+	expect(t, false, bus.Unsubscribe("calc", add) == nil, "the topic doesn't exist anymore")
+	expect(t, false, bus.Subscribe("calc", 42) == nil, "not a function")
+}
+
+func TestSubscribeOnceAndNilArgs(t *testing.T) {
+	bus := New(Emitter.Construct())
+
+	calls := 0
+	bus.SubscribeOnce("user", func(name string, err error) {
+		calls++
+		expect(t, "", name)
+		expect(t, nil, err)
+	})
+
+	bus.Publish("user", nil, nil)
+	bus.Publish("user", nil, nil)
+	expect(t, 1, calls)
+	expect(t, false, bus.HasCallback("user"))
+}
+
+func TestSubscribeAsync(t *testing.T) {
+	bus := New(Emitter.Construct())
+
+	var calls int32
+	order := []int{}
+	bus.SubscribeAsync("job", func(n int) { order = append(order, n) }, true)
+	bus.SubscribeOnceAsync("job", func(n int) { atomic.AddInt32(&calls, 1) })
+
+	for i := 0; i < 10; i++ {
+		bus.Publish("job", i)
+	}
+	bus.WaitAsync()
+
+	expect(t, int32(1), atomic.LoadInt32(&calls))
+	expect(t, 10, len(order))
+	for i, n := range order {
+		expect(t, i, n, "transactional handlers run in order")
+	}
+}
+
+func expect(t *testing.T, a interface{}, b interface{}, desc ...string) {
+	if a != b {
+		t.Errorf("%v Expected %v - Got %v", desc, a, b)
+	}
+}
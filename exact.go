@@ -0,0 +1,31 @@
+package Emitter
+
+import (
+	"errors"
+)
+
+// ErrWildcard - the pattern registrations on an emitter matching the exact names only are rejected
+var ErrWildcard = errors.New("emitter: wildcards are disabled, see WithExactMatchOnly")
+
+// WithExactMatchOnly() - register the listeners on exact event names only, the patterns with a `*` are
+// rejected, reported by a "schemaViolation" meta-event with ErrWildcard, so an emit looks its listeners
+// up in the map instead of scanning all of them
+func WithExactMatchOnly() Option {
+	return func(e *Emitter) {
+		e.exactOnly = true
+	}
+}
+
+// ExactMatchOnly() - whether the emitter matches the exact event names only
+func (self *Emitter) ExactMatchOnly() bool {
+	return self.exactOnly
+}
+
+// rejectWildcard() - whether the emitter matches the exact names only and the event is a pattern, reporting it
+func (self *Emitter) rejectWildcard(event string) bool {
+	if !self.exactOnly || !isPattern(event) {
+		return false
+	}
+	self.emitMeta("schemaViolation", event, ErrWildcard)
+	return true
+}
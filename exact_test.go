@@ -0,0 +1,49 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestWithExactMatchOnly(t *testing.T) {
+	emitter := New(WithExactMatchOnly())
+	expect(t, true, emitter.ExactMatchOnly())
+
+	calls := 0
+	emitter.On("user.created", func(args ...interface{}) { calls++ })
+	emitter.On("user.removed", func(args ...interface{}) { calls += 10 })
+	emitter.EmitSync("user.created")
+	expect(t, 1, calls)
+	expect(t, 1, emitter.ListenersCount("user.created"))
+	expect(t, 0, emitter.ListenersCount("user.updated"))
+
+	emitter.Alias("user.signup", "user.created")
+	emitter.EmitSync("user.signup")
+	expect(t, 2, calls, "the aliases are still resolved")
+
+	violations := []interface{}{}
+	emitter.On("schemaViolation", func(args ...interface{}) { violations = append(violations, args[1]) })
+	expect(t, ErrWildcard, emitter.CheckListen("user.*"))
+	emitter.On("user.*", func(...interface{}) {})
+	emitter.OnEvent("**", func(*Event) {})
+	emitter.OnEvents([]string{"a", "b.*"}, func(...interface{}) {})
+	emitter.OnPatterns([]string{"a.*"}, func(...interface{}) {})
+	emitter.MoveListeners("user.removed", "account.*")
+	expect(t, 5, len(violations), "each pattern is rejected and reported")
+	for _, err := range violations {
+		expect(t, ErrWildcard, err)
+	}
+	expect(t, 1, emitter.ListenersCount("a"), "the exact names are registered")
+	expect(t, 1, emitter.ListenersCount("user.removed"))
+	expect(t, 1, emitter.ListenersCount("user.created"), "the patterns aren't")
+}
+
+func BenchmarkExactMatchOnly(b *testing.B) {
+	emitter := New(WithExactMatchOnly())
+	for i := 0; i < 100; i++ {
+		emitter.On("service.event"+string(rune('a'+i%26))+string(rune('a'+i/26)), func(args ...interface{}) {})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		emitter.Listeners("service.eventqa")
+	}
+}
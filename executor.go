@@ -0,0 +1,129 @@
+package Emitter
+
+import (
+	"context"
+	"sync"
+)
+
+// Executor - runs tasks on a goroutine of its own, i.e a UI loop or a cgo-bound thread, see RunOn
+type Executor interface {
+	Execute(task func())
+}
+
+// ExecutorFunc - a function usable as an Executor
+type ExecutorFunc func(task func())
+
+func (self ExecutorFunc) Execute(task func()) {
+	self(task)
+}
+
+// ListenerOption - a setting of a listener registered by OnWith
+type ListenerOption func(*Listener)
+
+// RunOn() - run the listener's invocations on the executor instead of the goroutine of the emit or of the
+// pool, for the callbacks that must run on a specific goroutine; the emits don't wait for them, but the
+// ones reporting on the listeners: EmitFirst, EmitReduce, EmitAsyncResult and EmitAsyncWait
+func RunOn(executor Executor) ListenerOption {
+	return func(l *Listener) {
+		l.executor = executor
+	}
+}
+
+// callWait() - invoke the listener as call does, waiting for its executor, if any, to run it, its panic
+// raised again in the waiting goroutine: the emits using the listeners' results can't be run from the
+// executor's own goroutine
+func (self Listener) callWait(ev *Event) {
+	executor := self.executor
+	if executor == nil {
+		self.call(ev)
+		return
+	}
+	done := make(chan struct{})
+	var panicked interface{}
+	self.executor = nil
+	executor.Execute(func() {
+		defer close(done)
+		defer func() { panicked = recover() }()
+		self.call(ev)
+	})
+	<-done
+	if panicked != nil {
+		panic(panicked)
+	}
+}
+
+// OnWith() - register a new listener on the event with the options
+func (self *Emitter) OnWith(event string, callback func(...interface{}), opts ...ListenerOption) *Subscription {
+	listener := Listener{callback: callback}
+	for _, opt := range opts {
+		opt(&listener)
+	}
+	return self.addListenerInternal(event, listener)
+}
+
+// Loop - an executor running the tasks on the goroutine calling Run, i.e the main one, in their order
+type Loop struct {
+	tasks []func()
+	wake  chan struct{}
+	mutex *sync.Mutex
+}
+
+// NewLoop() - create a new loop, its tasks run once Run is called
+func NewLoop() *Loop {
+	return &Loop{wake: make(chan struct{}, 1), mutex: &sync.Mutex{}}
+}
+
+// Execute() - queue the task, it never blocks so the tasks of the loop can emit too
+func (self *Loop) Execute(task func()) {
+	self.mutex.Lock()
+	self.tasks = append(self.tasks, task)
+	self.mutex.Unlock()
+
+	select {
+	case self.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Run() - run the queued tasks on the calling goroutine until the context is done
+func (self *Loop) Run(ctx context.Context) error {
+	for {
+		self.mutex.Lock()
+		tasks := self.tasks
+		self.tasks = nil
+		self.mutex.Unlock()
+
+		for _, task := range tasks {
+			task()
+		}
+		if len(tasks) > 0 {
+			continue
+		}
+
+		select {
+		case <-self.wake:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Execution - how the invocations of a listener run, see RunAs
+type Execution int
+
+const (
+	// ExecutionInline - as the emit runs them: on the emitting goroutine for EmitSync, asynchronously for EmitAsync, the default
+	ExecutionInline Execution = iota
+	// ExecutionAsync - always on a goroutine of their own, even for EmitSync
+	ExecutionAsync
+	// ExecutionPool - always in the emitter's pool, even for EmitSync, on a goroutine of their own without pool
+	ExecutionPool
+)
+
+// RunAs() - run the listener's invocations per the execution, whatever the emit, so a single emit can
+// serve listeners with mixed requirements
+func RunAs(execution Execution) ListenerOption {
+	return func(l *Listener) {
+		l.execution = execution
+	}
+}
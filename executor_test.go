@@ -0,0 +1,108 @@
+package Emitter
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunOn(t *testing.T) {
+	emitter := New()
+	loop := NewLoop()
+	ctx, cancel := context.WithCancel(context.Background())
+
+	type call struct {
+		goroutine string
+		args      []interface{}
+	}
+	calls := make(chan call, 10)
+	goroutine := func() string {
+		buf := make([]byte, 64)
+		return strings.Fields(string(buf[:runtime.Stack(buf, false)]))[1]
+	}
+	sub := emitter.OnWith("user.created", func(args ...interface{}) {
+		calls <- call{goroutine(), args}
+		if args[0] == 1 {
+			emitter.EmitSync("user.created", 2)
+		}
+	}, RunOn(loop))
+
+	emitter.EmitSync("user.created", 1)
+	emitter.EmitAsync("user.created", []interface{}{3})
+	select {
+	case <-calls:
+		t.Fatal("the listener runs on the loop only")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	loopGoroutine := make(chan string, 1)
+	done := make(chan error)
+	go func() {
+		loopGoroutine <- goroutine()
+		done <- loop.Run(ctx)
+	}()
+	on := <-loopGoroutine
+	args := []interface{}{}
+	for i := 0; i < 3; i++ {
+		c := <-calls
+		expect(t, on, c.goroutine, "the listener runs on the loop's goroutine")
+		args = append(args, c.args[0])
+	}
+	expect(t, true, args[0] == 1 || args[0] == 3)
+	expect(t, 2, args[2], "the emits of the loop's tasks are queued")
+	expect(t, uint64(3), sub.Stats().Calls)
+
+	cancel()
+	expect(t, context.Canceled, <-done)
+}
+
+func TestRunAs(t *testing.T) {
+	pool := NewPool(1)
+	defer pool.Close()
+	emitter := New(WithPool(pool))
+
+	release := make(chan bool)
+	ran := make(chan string, 3)
+	emitter.OnWith("user.created", func(args ...interface{}) { ran <- "inline" })
+	emitter.OnWith("user.created", func(args ...interface{}) {
+		<-release
+		ran <- "async"
+	}, RunAs(ExecutionAsync))
+	emitter.OnWith("user.created", func(args ...interface{}) {
+		<-release
+		ran <- "pool"
+	}, RunAs(ExecutionPool))
+
+	emitter.EmitSync("user.created")
+	expect(t, "inline", <-ran, "the inline listener ran within the emit")
+	expect(t, 1, pool.Pending()+pool.Busy(), "the pooled listener runs in the pool")
+
+	release <- true
+	release <- true
+	got := map[string]bool{<-ran: true, <-ran: true}
+	expect(t, true, got["async"] && got["pool"])
+}
+
+func TestRunOnResults(t *testing.T) {
+	emitter := New()
+	loop := NewLoop()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go loop.Run(ctx)
+
+	ran := int32(0)
+	emitter.OnWith("user.created", func(args ...interface{}) { atomic.AddInt32(&ran, 1) }, RunOn(loop))
+	emitter.OnWith("user.created", func(args ...interface{}) { panic("boom") }, RunOn(loop))
+
+	result := emitter.EmitAsyncResult("user.created").Wait()
+	expect(t, int32(1), atomic.LoadInt32(&ran), "the result waits for the executor")
+	expect(t, 1, result.PanicCount())
+
+	err := emitter.EmitAsyncWait(context.Background(), "user.created")
+	expect(t, int32(2), atomic.LoadInt32(&ran))
+	_, panicked := err.(*PanicError)
+	expect(t, true, panicked, "the panic on the executor is returned")
+}
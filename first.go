@@ -0,0 +1,38 @@
+package Emitter
+
+// OnHandler() - register a new listener telling whether it handled the event, EmitFirst stops
+// at the first listener handling it while the other emits run it as any listener
+func (self *Emitter) OnHandler(event string, handler func(...interface{}) bool) *Subscription {
+	return self.addListenerInternal(event, Listener{handles: handler})
+}
+
+// EmitFirst() - run the listeners of the event in synchronous mode, in their order, until one
+// registered with OnHandler handles it (returns true), the chain of responsibility of i.e a
+// command routing; the plain listeners run but never handle it. Whether it was handled is returned
+func (self *Emitter) EmitFirst(event string, args ...interface{}) bool {
+	handled := false
+	self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		n := 0
+		for _, v := range self.listenersOf(ev.Name) {
+			if v.once && !self.claimOnce(v) {
+				continue
+			}
+			n++
+			if handles := v.handles; handles != nil {
+				v.handles = func(args ...interface{}) bool {
+					handled = handles(args...)
+					return handled
+				}
+			}
+			v.callWait(ev)
+			if handled {
+				break
+			}
+		}
+		return n
+	})
+	return handled
+}
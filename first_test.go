@@ -0,0 +1,53 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitFirst(t *testing.T) {
+	emitter := Construct()
+
+	calls := []string{}
+	emitter.On("cmd.*", func(args ...interface{}) { calls = append(calls, "log") })
+	emitter.OnHandler("cmd.*", func(args ...interface{}) bool {
+		calls = append(calls, "users")
+		return args[0] == "user"
+	})
+	emitter.OnHandler("cmd.run", func(args ...interface{}) bool {
+		calls = append(calls, "fallback")
+		return true
+	})
+
+	expect(t, true, emitter.EmitFirst("cmd.run", "user"))
+	expect(t, "log,users", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	expect(t, true, emitter.EmitFirst("cmd.run", "order"))
+	expect(t, "log,users,fallback", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	expect(t, false, emitter.EmitFirst("cmd.stop", "order"))
+
+	calls = calls[:0]
+	emitter.EmitSync("cmd.run", "user")
+	expect(t, "log,users,fallback", strings.Join(calls, ","), "plain emits run all the handlers")
+}
+
+func TestEmitFirstCall(t *testing.T) {
+	emitter := New()
+	sub := emitter.OnHandler("cmd.run", func(args ...interface{}) bool { return args[0] == "user" })
+	sub.update(func(l *Listener) { l.executor = ExecutorFunc(func(task func()) { go task() }) })
+
+	expect(t, true, emitter.EmitFirst("cmd.run", "user"), "the emit waits for the handler's executor")
+	expect(t, false, emitter.EmitFirst("cmd.run", "order"))
+	expect(t, uint64(2), sub.Stats().Calls)
+
+	panicking := New()
+	sub = panicking.OnHandler("cmd.run", func(args ...interface{}) bool { panic("boom") })
+	func() {
+		defer func() { recover() }()
+		panicking.EmitFirst("cmd.run")
+	}()
+	expect(t, uint64(1), sub.Stats().Panics, "the panics are counted")
+}
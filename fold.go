@@ -0,0 +1,69 @@
+package Emitter
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SetCaseInsensitive() - match the event names and patterns regardless of their (Unicode) case,
+// they are case folded when registering, emitting and removing, so "User.Created" reaches the
+// listeners of "user.*"; the listeners and aliases registered before are folded too
+func (self *Emitter) SetCaseInsensitive(insensitive bool) *Emitter {
+	if !self.lockUnfrozen() {
+		return self
+	}
+	defer self.mutex.Unlock()
+
+	self.caseInsensitive = insensitive
+	if !insensitive {
+		return self
+	}
+
+	listeners := make(map[interface{}][]Listener, len(self.listeners))
+	for event, lis := range self.listeners {
+		folded := FoldName(event.(string))
+		for _, l := range lis {
+			l.event = folded
+			listeners[folded] = append(listeners[folded], l)
+		}
+	}
+	self.listeners = listeners
+
+	if self.aliases != nil {
+		aliases, deprecated := make(map[string][]string), make(map[string]string)
+		for event, names := range self.aliases {
+			for _, name := range names {
+				aliases[FoldName(event)] = append(aliases[FoldName(event)], FoldName(name))
+			}
+		}
+		for old, new := range self.deprecated {
+			deprecated[FoldName(old)] = FoldName(new)
+		}
+		self.aliases, self.deprecated = aliases, deprecated
+	}
+	return self
+}
+
+// FoldName() - the case folded event name, the same for all the case variants of the name
+func FoldName(name string) string {
+	return strings.Map(foldRune, name)
+}
+
+// foldRune() - the smallest rune of the case folding orbit of the rune
+func foldRune(r rune) rune {
+	min := r
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f < min {
+			min = f
+		}
+	}
+	return min
+}
+
+// key() - the name the event is registered and matched under, the mutex must be held
+func (self *Emitter) key(event string) string {
+	if self.caseInsensitive {
+		return FoldName(event)
+	}
+	return event
+}
@@ -0,0 +1,25 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestCaseInsensitive(t *testing.T) {
+	emitter := Construct()
+	emitter.On("USER.Created", func(args ...interface{}) {})
+	emitter.SetCaseInsensitive(true)
+
+	names := []string{}
+	callback := func(args ...interface{}) { names = append(names, args[0].(string)) }
+	emitter.On("user.*", callback)
+	emitter.On("ΣΚΎΛΟΣ", callback)
+
+	emitter.EmitSync("User.Created", "user")
+	emitter.EmitSync("σκύλος", "greek")
+	expect(t, 2, len(names))
+	expect(t, 2, emitter.ListenersCount("user.CREATED"), "folded when enabled too")
+
+	emitter.RemoveListener("USER.*", callback)
+	expect(t, 1, emitter.ListenersCount("user.created"))
+	expect(t, FoldName("ΣΚΎΛΟΣ"), FoldName("σκύλος"))
+}
@@ -0,0 +1,55 @@
+package Emitter
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrFrozen - the listener registrations and removals on a frozen emitter are rejected
+var ErrFrozen = errors.New("emitter: frozen, its listeners can't be changed")
+
+// Freeze() - seal the emitter's topology, usually once the startup wiring is done: adding, removing,
+// copying or moving listeners and aliasing events are rejected from now on, reported by a
+// "schemaViolation" meta-event with ErrFrozen, while the listeners of each emitted event are
+// resolved once and then dispatched without locking. One-time listeners are still removed once they run.
+func (self *Emitter) Freeze() *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if !self.Frozen() {
+		self.table.Store(&sync.Map{})
+		atomic.StoreInt32(&self.frozen, 1)
+	}
+	return self
+}
+
+// Frozen() - whether the emitter is frozen
+func (self *Emitter) Frozen() bool {
+	return atomic.LoadInt32(&self.frozen) == 1
+}
+
+// lockUnfrozen() - lock the mutex to change the listeners of the events, false if the emitter is frozen:
+// the mutex isn't locked then and the rejection is reported for each event, or once without event
+func (self *Emitter) lockUnfrozen(events ...string) bool {
+	self.mutex.Lock()
+	if !self.Frozen() {
+		return true
+	}
+	self.mutex.Unlock()
+
+	if len(events) == 0 {
+		events = []string{""}
+	}
+	for _, event := range events {
+		self.emitMeta("schemaViolation", event, ErrFrozen)
+	}
+	return false
+}
+
+// invalidate() - drop the resolved listeners of the frozen emitter, the mutex must be held
+func (self *Emitter) invalidate() {
+	if self.Frozen() {
+		self.table.Store(&sync.Map{})
+	}
+}
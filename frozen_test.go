@@ -0,0 +1,53 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	emitter.On("user.*", func(args ...interface{}) { counter++ })
+	emitter.Once("user.created", func(args ...interface{}) { counter += 10 })
+	emitter.Freeze()
+	expect(t, true, emitter.Frozen())
+
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("user.created")
+	expect(t, 12, counter, "the once listener still runs once")
+	expect(t, 1, emitter.ListenersCount("user.created"))
+
+	expect(t, ErrFrozen, emitter.CheckListen("user.deleted"))
+
+	sub := emitter.OnEvent("user.deleted", func(*Event) { counter += 100 })
+	emitter.RemoveAllListeners(nil)
+	emitter.MoveListeners("user.*", "account.*")
+	emitter.Alias("user.signup", "user.created")
+	emitter.OnEvents([]string{"user.a", "user.b"}, func(...interface{}) {})
+	sub.Remove()
+
+	// still usable after the rejected mutations
+	emitter.EmitSync("user.deleted")
+	expect(t, 13, counter)
+	expect(t, 1, emitter.ListenersCount("user.created"))
+}
+
+func TestFreezeReportsRejections(t *testing.T) {
+	emitter := Construct()
+	violations := []interface{}{}
+	emitter.On("schemaViolation", func(args ...interface{}) { violations = append(violations, args[1]) })
+	emitter.Freeze()
+
+	sub := emitter.OnEvent("user.deleted", func(*Event) {})
+	emitter.RemoveAllListeners(nil)
+	emitter.MoveListeners("user.*", "account.*")
+	emitter.Alias("user.signup", "user.created")
+	emitter.OnEvents([]string{"user.a", "user.b"}, func(...interface{}) {})
+	sub.Remove()
+	expect(t, 7, len(violations), "each rejected mutation is reported")
+	for _, err := range violations {
+		expect(t, ErrFrozen, err)
+	}
+	expect(t, 1, emitter.ListenersCount("schemaViolation"))
+}
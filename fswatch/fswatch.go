@@ -0,0 +1,97 @@
+// Package fswatch watches paths with fsnotify and emits their changes into an emitter,
+// serving config reloads and the like with ordinary listeners.
+//
+// Every change is emitted as "<prefix><op>:<path>" with the path as its only arg,
+// i.e "fs.write:/etc/app.yaml", where op is one of create, write, remove, rename
+// and chmod; watching errors are emitted as "<prefix>error" with the error.
+//
+// Only the programs importing it build fsnotify.
+package fswatch
+
+import (
+	"github.com/fsnotify/fsnotify"
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// ops - the event names of the fsnotify operations
+var ops = []struct {
+	op   fsnotify.Op
+	name string
+}{
+	{fsnotify.Create, "create"},
+	{fsnotify.Write, "write"},
+	{fsnotify.Remove, "remove"},
+	{fsnotify.Rename, "rename"},
+	{fsnotify.Chmod, "chmod"},
+}
+
+// Watcher - emits the changes of the watched paths
+type Watcher struct {
+	emitter *Emitter.Emitter
+	prefix  string
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// Watch() - watch the paths, emitting their changes as "<prefix><op>:<path>" events
+func Watch(e *Emitter.Emitter, prefix string, paths ...string) (*Watcher, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	w := &Watcher{e, prefix, watcher, make(chan struct{})}
+	for _, path := range paths {
+		if err := watcher.Add(path); err != nil {
+			watcher.Close()
+			return nil, err
+		}
+	}
+
+	go w.run()
+	return w, nil
+}
+
+// Add() - start watching another path
+func (self *Watcher) Add(path string) error {
+	return self.watcher.Add(path)
+}
+
+// Remove() - stop watching the path
+func (self *Watcher) Remove(path string) error {
+	return self.watcher.Remove(path)
+}
+
+// Close() - stop watching, no event is emitted once it returns
+func (self *Watcher) Close() error {
+	err := self.watcher.Close()
+	<-self.done
+	return err
+}
+
+// EventName() - the name of the event emitted for the operation on the path
+func EventName(prefix, op, path string) string {
+	return prefix + op + ":" + path
+}
+
+func (self *Watcher) run() {
+	defer close(self.done)
+	for {
+		select {
+		case ev, ok := <-self.watcher.Events:
+			if !ok {
+				return
+			}
+			for _, op := range ops {
+				if ev.Op&op.op != 0 {
+					self.emitter.EmitSync(EventName(self.prefix, op.name, ev.Name), ev.Name)
+				}
+			}
+		case err, ok := <-self.watcher.Errors:
+			if !ok {
+				return
+			}
+			self.emitter.EmitSync(self.prefix+"error", err)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package fswatch
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestWatch(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fswatch")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "app.yaml")
+	ioutil.WriteFile(path, []byte("a: 1"), 0644)
+
+	emitter := Emitter.Construct()
+	received := make(chan string, 10)
+	emitter.On("fs.write:*app.yaml", func(args ...interface{}) {
+		received <- args[0].(string)
+	})
+
+	watcher, err := Watch(emitter, "fs.", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer watcher.Close()
+
+	ioutil.WriteFile(path, []byte("a: 2"), 0644)
+
+	select {
+	case got := <-received:
+		if got != path {
+			t.Errorf("Expected %v - Got %v", path, got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("write not emitted")
+	}
+}
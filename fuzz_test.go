@@ -0,0 +1,30 @@
+//go:build go1.18
+// +build go1.18
+
+package Emitter
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func FuzzMatch(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"user.*", "user.created"},
+		{"**", "anything"},
+		{"*a*a*a*a", "aaaaaaaaaaaaaaaaaaaaaaaaaaab"},
+		{"a*b*c", "abc"},
+		{"", ""},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, pattern, event string) {
+		if !utf8.ValidString(pattern) || !utf8.ValidString(event) || len(pattern) > 64 || len(event) > 256 {
+			t.Skip()
+		}
+		if Match(pattern, event) != referenceMatch(pattern, event) {
+			t.Errorf("Match(%q, %q) = %v, the regexp translation disagrees", pattern, event, Match(pattern, event))
+		}
+	})
+}
@@ -0,0 +1,33 @@
+package Emitter
+
+// gate - decides which emits are delivered, see WithGate
+type gate struct {
+	open    func(event string) bool
+	counted bool
+}
+
+// WithGate() - consult the gate on each emit, the ones it closes aren't delivered, so the events can be
+// enabled or disabled by feature flags or build modes without touching the producers; the meta-events
+// aren't gated
+func WithGate(open func(event string) bool) Option {
+	return func(e *Emitter) {
+		e.gate = &gate{open: open}
+	}
+}
+
+// WithCountedGate() - consult the gate on each emit, as WithGate does, still counting the gated emits
+// in the emits report, see TrackReport
+func WithCountedGate(open func(event string) bool) Option {
+	return func(e *Emitter) {
+		e.gate = &gate{open: open, counted: true}
+	}
+}
+
+// gated() - whether the emit is closed by the gate, and whether it's still counted
+func (self *Emitter) gated(event string) (bool, bool) {
+	gate := self.gate
+	if gate == nil || IsMetaEvent(event) || gate.open(event) {
+		return false, false
+	}
+	return true, gate.counted
+}
@@ -0,0 +1,38 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWithGate(t *testing.T) {
+	flags := map[string]bool{"beta": false}
+	open := func(event string) bool { return !strings.HasPrefix(event, "beta.") || flags["beta"] }
+
+	for _, counted := range []bool{false, true} {
+		option := WithGate(open)
+		if counted {
+			option = WithCountedGate(open)
+		}
+		emitter := New(option)
+		emitter.TrackReport(time.Minute, 5)
+		delivered := 0
+		emitter.On("beta.feature", func(args ...interface{}) { delivered++ })
+		emitter.On("user.created", func(args ...interface{}) { delivered++ })
+
+		flags["beta"] = false
+		emitter.EmitSync("beta.feature")
+		emitter.EmitSync("user.created")
+		expect(t, 1, delivered, "the gated emits aren't delivered")
+		reported := 1
+		if counted {
+			reported = 2
+		}
+		expect(t, reported, len(emitter.Report().Emits), "only the counted gated emits are reported")
+
+		flags["beta"] = true
+		emitter.EmitSync("beta.feature")
+		expect(t, 2, delivered)
+	}
+}
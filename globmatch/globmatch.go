@@ -0,0 +1,77 @@
+// Package globmatch matches the emitter's patterns with the gobwas/glob engine,
+// adding `{a,b}` alternations, `?` and `[a-z]`/`[!a-z]` character classes to the
+// wildcards:
+//
+//	e := Emitter.New(Emitter.WithMatcher(globmatch.New('.')))
+//	e.On("user.{created,updated}", fn)
+//	e.On("order.[0-9]*.paid", fn)
+//
+// With separators `*` stops at them and `**` spans them, without any `*` spans
+// everything like in the default dialect. A pattern the engine can't parse
+// matches the event of the same name only.
+package globmatch
+
+import (
+	"sync"
+
+	"github.com/gobwas/glob"
+)
+
+// Matcher - the gobwas/glob dialect, an Emitter.CompilingMatcher
+type Matcher struct {
+	separators []rune
+	globs      *sync.Map
+}
+
+// New() - create a new matcher, `*` not spanning the separators
+func New(separators ...rune) *Matcher {
+	return &Matcher{separators, &sync.Map{}}
+}
+
+// Match() - report whether the event name matches the pattern, the compiled patterns are cached
+func (self *Matcher) Match(pattern, event string) bool {
+	if fn, ok := self.globs.Load(pattern); ok {
+		return fn.(func(string) bool)(event)
+	}
+	fn := self.Compile(pattern)
+	self.globs.Store(pattern, fn)
+	return fn(event)
+}
+
+// Compile() - parse the pattern once
+func (self *Matcher) Compile(pattern string) func(event string) bool {
+	if pattern == "**" {
+		return func(string) bool { return true }
+	}
+	if !balanced(pattern) {
+		return func(event string) bool { return event == pattern }
+	}
+	g, err := glob.Compile(pattern, self.separators...)
+	if err != nil {
+		return func(event string) bool { return event == pattern }
+	}
+	return g.Match
+}
+
+// balanced() - whether the pattern's `{` and `[` are closed, the engine compiling some unclosed ones
+// to a glob that never matches
+func balanced(pattern string) bool {
+	braces, class := 0, false
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\':
+			i++
+		case class:
+			class = c != ']'
+		case c == '[':
+			class = true
+		case c == '{':
+			braces++
+		case c == '}':
+			if braces--; braces < 0 {
+				return false
+			}
+		}
+	}
+	return braces == 0 && !class
+}
@@ -0,0 +1,41 @@
+package globmatch
+
+import (
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	t.Helper()
+	if a != b {
+		t.Errorf("Expected %v (type %T) - Got %v (type %T)", a, a, b, b)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m := New('.')
+	expect(t, true, m.Match("user.{created,updated}", "user.created"))
+	expect(t, false, m.Match("user.{created,updated}", "user.removed"))
+	expect(t, true, m.Match("order.[0-9]*.paid", "order.42.paid"))
+	expect(t, false, m.Match("order.[0-9]*.paid", "order.x.paid"))
+	expect(t, false, m.Match("user.*", "user.profile.updated"))
+	expect(t, true, m.Match("user.**", "user.profile.updated"))
+	expect(t, true, m.Match("**", "anything.at.all"))
+	expect(t, true, m.Match("user.{", "user.{"))
+	expect(t, false, m.Match("user.{", "user.x"))
+	expect(t, true, m.Match("order.[0-9", "order.[0-9"))
+	expect(t, true, m.Match("a.}", "a.}"))
+
+	expect(t, true, New().Match("user.*", "user.profile.updated"))
+}
+
+func TestWithMatcher(t *testing.T) {
+	e := Emitter.New(Emitter.WithMatcher(New('.')))
+	calls := 0
+	e.On("user.{created,updated}", func(args ...interface{}) { calls++ })
+	e.EmitSync("user.created")
+	e.EmitSync("user.updated")
+	e.EmitSync("user.removed")
+	expect(t, 2, calls)
+}
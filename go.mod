@@ -1,3 +1,8 @@
 module github.com/moleculer-go/goemitter
 
 go 1.12
+
+require (
+	github.com/fsnotify/fsnotify v1.7.0
+	github.com/gobwas/glob v0.2.3
+)
@@ -5,54 +5,396 @@
 package Emitter
 
 import (
+	"context"
 	"reflect"
+	"regexp"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
-// wildcard helper
+// wildcard helper, iterative: on a mismatch only the last star is retried one character further,
+// the earlier stars never need to, so it runs in O(len(eventName) * len(pattern)) at worst
 func eventMatchPattern(eventName, pattern []rune) bool {
-	for len(pattern) > 0 {
-		switch pattern[0] {
-		case '*':
-			return eventMatchPattern(eventName, pattern[1:]) || (len(eventName) > 0 && eventMatchPattern(eventName[1:], pattern))
-
+	e, p := 0, 0
+	star, retry := -1, 0
+	for e < len(eventName) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, retry = p, e
+			p++
+		case p < len(pattern) && pattern[p] == eventName[e]:
+			e++
+			p++
+		case star >= 0:
+			retry++
+			e, p = retry, star+1
 		default:
-			if len(eventName) == 0 || eventName[0] != pattern[0] {
-				return false
-			}
+			return false
 		}
-
-		eventName = eventName[1:]
-		pattern = pattern[1:]
 	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
+
+// Match() - report whether the event name matches the (wildcard) pattern
+func Match(pattern, event string) bool {
+	return pattern == "**" || pattern == event ||
+		(strings.Contains(pattern, "*") && eventMatchPattern([]rune(event), []rune(pattern)))
+}
 
-	return len(eventName) == 0 && len(pattern) == 0
+// HeaderOrigin - the header holding the id of the bridge an event was received from
+const HeaderOrigin = "origin"
+
+// Event - the envelope of a single emit, as seen by event listeners
+type Event struct {
+	Name    string            `json:"name"`
+	Args    []interface{}     `json:"args"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Pattern - the pattern of the listener receiving the event, i.e "user.*", set on each delivery
+	Pattern string `json:"-"`
+	// Params - the values of the named parameters of the pattern of the listener receiving the event,
+	// i.e {"id": "42"} for "user.{id}.updated", set on each delivery
+	Params map[string]string `json:"-"`
+	// Priority - the rank of the async invocations of the event in the emitter's pool queue, see PriorityHigh ...
+	Priority int `json:"-"`
+	// ctx - the context of the emit, see EmitContext
+	ctx context.Context
+	// failed - collects the errors of the listeners, see OnContextErr
+	failed func(error)
+	// discarded - set once the expired event is discarded, so it's reported once, see EmitTTL
+	discarded *int32
+}
+
+// IsMetaEvent() - whether the event is one of the emitter's own meta-events ("newListener", "removeListener" ...)
+func IsMetaEvent(event string) bool {
+	return event == "newListener" || event == "removeListener" || event == "schemaViolation" ||
+		event == "limitExceeded" || event == "eventExpired" || IsLifecycleEvent(event)
 }
 
 // Emitter - our listeners container
 type Emitter struct {
-	listeners map[interface{}][]Listener
-	mutex     *sync.Mutex
+	listeners       map[interface{}][]Listener
+	mutex           *sync.Mutex
+	sequence        uint64
+	schemas         map[string]Schema
+	schemaMode      SchemaMode
+	aliases         map[string][]string
+	deprecated      map[string]string
+	onDeprecated    func(old, new string)
+	frozen          int32
+	table           atomic.Value
+	leaks           *leakDetector
+	caseInsensitive bool
+	reservations    []*Reservation
+	addedHooks      []func(event string, l Listener)
+	removedHooks    []func(event string, l Listener)
+	excludeMeta     bool
+	dedupe          bool
+	balancing       map[balanceKey]uint64
+	limits          []*concurrencyLimit
+	pool            *Pool
+	timeoutPolicy   TimeoutPolicy
+	values          map[string]interface{}
+	comparators     map[string]func(a, b interface{}) bool
+	journals        []*Journal
+	schedules       *scheduler
+	recorders       []*Recorder
+	clock           Clock
+	patterns        map[string]*compiledPattern
+	matcher         Matcher
+	exactOnly       bool
+	reporter        atomic.Value
+	health          *HealthPolicy
+	samplers        []*sampler
+	samplingMode    SamplingMode
+	tenancy         *tenancy
+	tenants         map[string]*Emitter
+	sourceDepth     int
+	auth            func(op Op, event string) error
+	paused          bool
+	closed          bool
+	held            []held
+	saturated       int32
+	validators      []validator
+	deadLetters     func(ev *Event, err error)
+	envelope        EnvelopeLimits
+	migrations      map[string]map[int]Migration
+	emitHooks       *emitHooks
+	mutes           []*mute
+	gate            *gate
+	poolSize        int
+	handlers        map[string]func(...interface{})
+	// serial - the order of creation of the emitter, locking several ones in this order
+	serial uint64
 }
 
 // Listener - our callback container and whether it will run once or not
 type Listener struct {
 	callback func(...interface{})
+	handler  func(*Event)
+	handles  func(...interface{}) bool
+	reduces  func(interface{}, ...interface{}) interface{}
 	once     bool
+	event    string
+	id       uint64
+	site     string
+	created  time.Time
+
+	excludeMeta bool
+	except      []string
+	dedupe      bool
+	group       string
+	bridge      bool
+	stats       *invocations
+	// origin - the identity of the function the user registered, when the listener runs a wrapper of it
+	origin uintptr
+	// executor - runs the invocations of the listener, see RunOn
+	executor Executor
+	// execution - whether the emit decides how the invocations run, see RunAs
+	execution Execution
+	// params - the regexp of the named parameters of the matched pattern, set once it is collected
+	params *regexp.Regexp
+}
+
+// Subscription - the handle of a registered listener
+type Subscription struct {
+	emitter  *Emitter
+	event    string
+	id       uint64
+	patterns []string
+	stats    *invocations
+}
+
+// call() - invoke the listener with the specified event, on its executor if any, counting the invocation and its panic
+func (self Listener) call(ev *Event) {
+	if executor := self.executor; executor != nil {
+		self.executor = nil
+		executor.Execute(func() { self.call(ev) })
+		return
+	}
+	if self.stats == nil {
+		self.invoke(ev)
+		return
+	}
+	self.stats.record()
+	if r := self.stats.emitter.reporting(); r != nil {
+		clock := self.stats.emitter.Clock()
+		defer r.timed(self, clock, clock.Now())
+	}
+	returned := false
+	defer self.stats.returned(&returned)
+	self.invoke(ev)
+	returned = true
+}
+
+// invoke() - run the listener's function with the event
+func (self Listener) invoke(ev *Event) {
+	if self.handler != nil {
+		if ev.Pattern != self.event || ev.Params != nil || self.params != nil {
+			delivered := *ev
+			delivered.Pattern = self.event
+			delivered.Params = paramsOf(self.params, ev.Name)
+			ev = &delivered
+		}
+		self.handler(ev)
+		return
+	}
+	if self.handles != nil {
+		self.handles(ev.Args...)
+		return
+	}
+	if self.reduces != nil {
+		self.reduces(nil, ev.Args...)
+		return
+	}
+	self.callback(ev.Args...)
+}
+
+// is() - whether the specified listener is the same registration
+func (self Listener) is(other Listener) bool {
+	return self.id == other.id
 }
 
-// Construct() - create a new instance of Emitter
+// function() - the registered listener function
+func (self Listener) function() interface{} {
+	if self.handler != nil {
+		return self.handler
+	}
+	if self.handles != nil {
+		return self.handles
+	}
+	if self.reduces != nil {
+		return self.reduces
+	}
+	return self.callback
+}
+
+// pointer() - the identity of the listener function, the one registered by the user if it's wrapped
+func (self Listener) pointer() uintptr {
+	if self.origin != 0 {
+		return self.origin
+	}
+	return reflect.ValueOf(self.function()).Pointer()
+}
+
+// wrap() - the listener running the wrapper instead of its callback, still removed by the callback
+func (self Listener) wrap(wrapper func(...interface{})) Listener {
+	if self.origin == 0 {
+		self.origin = self.pointer()
+	}
+	self.callback = wrapper
+	return self
+}
+
+// Option - a setting of the emitter, applied by New
+type Option func(*Emitter)
+
+// New() - create a new instance of Emitter with the options applied
+func New(opts ...Option) *Emitter {
+	emitter := &Emitter{
+		listeners: make(map[interface{}][]Listener),
+		mutex:     &sync.Mutex{},
+		serial:    nextSerial(),
+	}
+	for _, opt := range opts {
+		opt(emitter)
+	}
+	if emitter.poolSize > 0 {
+		if emitter.pool == nil {
+			emitter.pool = NewPool(emitter.poolSize)
+		} else {
+			emitter.pool.Resize(emitter.poolSize)
+		}
+	}
+	if emitter.pool != nil && emitter.clock != nil {
+		emitter.pool.adoptClock(emitter.clock)
+	}
+	emitter.emitMeta(EventConstructed, emitter)
+	return emitter
+}
+
+// Construct() - create a new instance of Emitter, kept for compatibility, see New
 func Construct() *Emitter {
-	return &Emitter{
-		make(map[interface{}][]Listener),
-		&sync.Mutex{},
+	return New()
+}
+
+// Clone() - create a new emitter with copies of the listeners, schemas and aliases of this one,
+// i.e to seed per-test or per-tenant emitters from a template, the clone isn't frozen
+func (self *Emitter) Clone() *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	clone := Construct()
+	clone.sequence = self.sequence
+	clone.schemaMode = self.schemaMode
+	clone.onDeprecated = self.onDeprecated
+	clone.caseInsensitive = self.caseInsensitive
+	clone.excludeMeta = self.excludeMeta
+	clone.dedupe = self.dedupe
+	clone.pool = self.pool
+	clone.timeoutPolicy = self.timeoutPolicy
+	clone.clock = self.clock
+	clone.matcher = self.matcher
+	clone.exactOnly = self.exactOnly
+	clone.health = self.health
+	clone.sourceDepth = self.sourceDepth
+	clone.auth = self.auth
+	clone.envelope = self.envelope
+	clone.emitHooks = self.emitHooks
+	clone.gate = self.gate
+	clone.validators = append(clone.validators, self.validators...)
+	clone.deadLetters = self.deadLetters
+	clone.samplingMode = self.samplingMode
+	for _, m := range self.mutes {
+		clone.mutes = append(clone.mutes, &mute{pattern: m.pattern, counted: m.counted})
+	}
+	for _, s := range self.samplers {
+		clone.samplers = append(clone.samplers, &sampler{pattern: s.pattern, rate: s.rate})
+	}
+	clone.addedHooks = append(clone.addedHooks, self.addedHooks...)
+	clone.removedHooks = append(clone.removedHooks, self.removedHooks...)
+	for _, limit := range self.limits {
+		clone.limits = append(clone.limits, newConcurrencyLimit(limit.pattern, cap(limit.slots)))
+	}
+	if self.leaks != nil {
+		clone.leaks = &leakDetector{maxAge: self.leaks.maxAge}
+	}
+	// the clone counts its own invocations, the copies of a registration still share theirs
+	stats := map[uint64]*invocations{}
+	for event, listeners := range self.listeners {
+		clone.listeners[event] = append([]Listener{}, listeners...)
+		for i, l := range clone.listeners[event] {
+			if stats[l.id] == nil {
+				stats[l.id] = &invocations{emitter: clone}
+			}
+			clone.listeners[event][i].stats = stats[l.id]
+		}
 	}
+	if self.schemas != nil {
+		clone.schemas = make(map[string]Schema, len(self.schemas))
+		for event, schema := range self.schemas {
+			clone.schemas[event] = schema
+		}
+	}
+	if self.migrations != nil {
+		clone.migrations = make(map[string]map[int]Migration, len(self.migrations))
+		for event, migrations := range self.migrations {
+			clone.migrations[event] = make(map[int]Migration, len(migrations))
+			for from, migration := range migrations {
+				clone.migrations[event][from] = migration
+			}
+		}
+	}
+	if self.handlers != nil {
+		clone.handlers = make(map[string]func(...interface{}), len(self.handlers))
+		for name, handler := range self.handlers {
+			clone.handlers[name] = handler
+		}
+	}
+	if self.aliases != nil {
+		clone.aliases = make(map[string][]string, len(self.aliases))
+		clone.deprecated = make(map[string]string, len(self.deprecated))
+		for event, aliases := range self.aliases {
+			clone.aliases[event] = append([]string{}, aliases...)
+		}
+		for old, new := range self.deprecated {
+			clone.deprecated[old] = new
+		}
+	}
+	return clone
 }
 
-// Destruct() - free memory from an emitter instance
+// Close() - release the emitter, emitting EventClosing then EventClosed, the listeners still registered
+// are then reported as leaks
+func (self *Emitter) Close() error {
+	self.mutex.Lock()
+	closed := self.closed
+	self.closed = true
+	self.mutex.Unlock()
+
+	if closed {
+		return nil
+	}
+	self.emitMeta(EventClosing, self)
+
+	self.mutex.Lock()
+	if self.leaks != nil {
+		self.leaks.destructed = true
+	}
+	self.mutex.Unlock()
+
+	self.emitMeta(EventClosed, self)
+	return nil
+}
+
+// Destruct() - free memory from an emitter instance, kept for compatibility, see Close
 func (self *Emitter) Destruct() {
-	self = nil
+	self.Close()
 }
 
 // AddListener() - register a new listener on the specified event
@@ -62,37 +404,113 @@ func (self *Emitter) AddListener(event string, callback func(...interface{})) *E
 
 // On() - register a new listener on the specified event
 func (self *Emitter) On(event string, callback func(...interface{})) *Emitter {
-	self.mutex.Lock()
-	if _, ok := self.listeners[event]; !ok {
-		self.listeners[event] = []Listener{}
-	}
-	self.listeners[event] = append(self.listeners[event], Listener{callback, false})
-	self.mutex.Unlock()
-
-	self.EmitSync("newListener", []interface{}{event, callback})
+	self.addListenerInternal(event, Listener{callback: callback})
 	return self
 }
 
 // Once() - register a new one-time listener on the specified event
 func (self *Emitter) Once(event string, callback func(...interface{})) *Emitter {
-	self.mutex.Lock()
+	self.addListenerInternal(event, Listener{callback: callback, once: true})
+	return self
+}
+
+// OnEvent() - register a new listener receiving the whole event envelope on the specified event
+func (self *Emitter) OnEvent(event string, handler func(*Event)) *Subscription {
+	return self.addListenerInternal(event, Listener{handler: handler})
+}
+
+func (self *Emitter) addListenerInternal(event string, listener Listener) *Subscription {
+	if self.reserved(event, false) {
+		return &Subscription{emitter: self, event: event}
+	}
+	return self.addListener(event, listener)
+}
+
+// addListener() - register the listener, even on reserved events
+func (self *Emitter) addListener(event string, listener Listener) *Subscription {
+	if self.rejectWildcard(event) {
+		return &Subscription{emitter: self, event: event}
+	}
+	if !self.lockUnfrozen(event) {
+		return &Subscription{emitter: self, event: event}
+	}
+	event = self.key(event)
+	self.sequence++
+	listener.event = event
+	listener.id = self.sequence
+	self.track(&listener)
+	self.compiled(event)
 	if _, ok := self.listeners[event]; !ok {
 		self.listeners[event] = []Listener{}
 	}
-	self.listeners[event] = append(self.listeners[event], Listener{callback, true})
+	self.listeners[event] = append(self.listeners[event], listener)
 	self.mutex.Unlock()
 
-	self.EmitSync("newListener", []interface{}{event, callback})
-	return self
+	self.validateListener(event)
+	self.deprecation(event)
+	self.listenerAdded(event, listener)
+	return &Subscription{emitter: self, event: event, id: listener.id, stats: listener.stats}
 }
 
-// RemoveListeners() - remove the specified callback from the specified events' listeners
-func (self *Emitter) RemoveListener(event string, callback func(...interface{})) *Emitter {
-	return self.removeListenerInternal(event, callback, false)
+// Remove() - remove the subscribed listener from its emitter
+func (self *Subscription) Remove() {
+	if self.patterns != nil {
+		self.emitter.removeEventsInternal(self.keys(), Listener{id: self.id}.is)
+		return
+	}
+	self.emitter.removeListenerInternal(self.emitter.eventOf(self.event, self.id), Listener{id: self.id}.is, false)
 }
 
-func (self *Emitter) removeListenerInternal(event string, callback func(...interface{}), suppress bool) *Emitter {
+// keys() - the events the subscribed listener is currently registered on
+func (self *Subscription) keys() []string {
+	if self.patterns == nil {
+		return []string{self.emitter.eventOf(self.event, self.id)}
+	}
+	keys := make([]string, len(self.patterns))
+	for i, pattern := range self.patterns {
+		keys[i] = self.emitter.eventOf(pattern, self.id)
+	}
+	return keys
+}
+
+// eventOf() - the event the listener is currently registered on, it changes when moved
+func (self *Emitter) eventOf(event string, id uint64) string {
 	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	event = self.key(event)
+	for _, l := range self.listeners[event] {
+		if l.id == id {
+			return event
+		}
+	}
+	for e, lis := range self.listeners {
+		for _, l := range lis {
+			if l.id == id {
+				return e.(string)
+			}
+		}
+	}
+	return event
+}
+
+// RemoveListeners() - remove the specified callback from the specified events' listeners, kept for
+// compatibility: the callbacks are compared by function pointer, which can't tell apart the method
+// values nor the closures of the same code, see Subscribe and RemoveSubscription
+func (self *Emitter) RemoveListener(event string, callback func(...interface{})) *Emitter {
+	ptr := Listener{callback: callback}.pointer()
+	return self.removeListenerInternal(event, func(l Listener) bool {
+		return l.handler == nil && l.pointer() == ptr
+	}, false)
+}
+
+func (self *Emitter) removeListenerInternal(event string, match func(Listener) bool, suppress bool) *Emitter {
+	if suppress {
+		self.mutex.Lock()
+	} else if !self.lockUnfrozen(event) {
+		return self
+	}
+	event = self.key(event)
 
 	if _, ok := self.listeners[event]; !ok {
 		self.mutex.Unlock()
@@ -100,13 +518,14 @@ func (self *Emitter) removeListenerInternal(event string, callback func(...inter
 	}
 
 	for k, v := range self.listeners[event] {
-		if reflect.ValueOf(v.callback).Pointer() == reflect.ValueOf(callback).Pointer() {
+		if match(v) {
 			self.listeners[event] = append(self.listeners[event][:k], self.listeners[event][k+1:]...)
+			self.invalidate()
 
 			self.mutex.Unlock()
 
 			if !suppress {
-				self.EmitSync("removeListener", []interface{}{event, callback})
+				self.listenerRemoved(event, v)
 			}
 			return self
 		}
@@ -117,15 +536,37 @@ func (self *Emitter) removeListenerInternal(event string, callback func(...inter
 	return self
 }
 
+// claimOnce() - remove the one-time listener before running it, false if a concurrent emit already did
+func (self *Emitter) claimOnce(listener Listener) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	listeners := self.listeners[listener.event]
+	for k, v := range listeners {
+		if v.id == listener.id {
+			self.listeners[listener.event] = append(listeners[:k], listeners[k+1:]...)
+			self.invalidate()
+			return true
+		}
+	}
+	return false
+}
+
 // RemoveAllListeners() - remove all listeners from (all/event)
 func (self *Emitter) RemoveAllListeners(event interface{}) *Emitter {
-	self.mutex.Lock()
+	name, _ := event.(string)
+	if !self.lockUnfrozen(name) {
+		return self
+	}
 	defer self.mutex.Unlock()
 
 	if event == nil {
 		self.listeners = make(map[interface{}][]Listener)
 		return self
 	}
+	if _, ok := event.(string); ok {
+		event = self.key(name)
+	}
 	if _, ok := self.listeners[event]; !ok {
 		return self
 	}
@@ -133,58 +574,297 @@ func (self *Emitter) RemoveAllListeners(event interface{}) *Emitter {
 	return self
 }
 
-// Listeners() - return an array with the registered listeners in the specified event
+// CopyListeners() - register copies of the listeners of the event (pattern) on another one, atomically
+func (self *Emitter) CopyListeners(from, to string) *Emitter {
+	if self.rejectWildcard(to) || !self.lockUnfrozen(from) {
+		return self
+	}
+	from, to = self.key(from), self.key(to)
+	copied := self.copyListenersInternal(from, to)
+	self.mutex.Unlock()
+
+	for _, l := range copied {
+		self.listenerAdded(to, l)
+	}
+	return self
+}
+
+// MoveListeners() - move the listeners of the event (pattern) to another one, atomically,
+// their subscriptions follow them
+func (self *Emitter) MoveListeners(from, to string) *Emitter {
+	if self.rejectWildcard(to) || !self.lockUnfrozen(from) {
+		return self
+	}
+	from, to = self.key(from), self.key(to)
+	moved := self.listeners[from]
+	if from == to || len(moved) == 0 {
+		self.mutex.Unlock()
+		return self
+	}
+	for i := range moved {
+		moved[i].event = to
+	}
+	self.listeners[to] = append(self.listeners[to], moved...)
+	delete(self.listeners, from)
+	self.mutex.Unlock()
+
+	for _, l := range moved {
+		self.listenerRemoved(from, l)
+		self.listenerAdded(to, l)
+	}
+	return self
+}
+
+// copyListenersInternal() - append copies of the listeners of from to the ones of to, the mutex must be held
+func (self *Emitter) copyListenersInternal(from, to string) []Listener {
+	if from == to {
+		return nil
+	}
+
+	copies := make([]Listener, 0, len(self.listeners[from]))
+	for _, l := range self.listeners[from] {
+		self.sequence++
+		l.event = to
+		l.id = self.sequence
+		l.stats = &invocations{emitter: self}
+		copies = append(copies, l)
+	}
+	if len(copies) > 0 {
+		self.listeners[to] = append(self.listeners[to], copies...)
+	}
+	return copies
+}
+
+// Listeners() - return an array with the registered listeners in the specified event, in their registration order
 func (self *Emitter) Listeners(event string) []Listener {
+	return append([]Listener{}, self.listenersOf(event)...)
+}
+
+// listenersOf() - the listeners of the event, read from the dispatch table once frozen, they must not be modified
+func (self *Emitter) listenersOf(event string) []Listener {
+	if !self.Frozen() {
+		return self.collectListeners(event)
+	}
+
+	table := self.table.Load().(*sync.Map)
+	if listeners, ok := table.Load(event); ok {
+		return listeners.([]Listener)
+	}
+	listeners := self.collectListeners(event)
+	table.Store(event, listeners)
+	return listeners
+}
+
+// collectListeners() - gather the listeners whose pattern matches the event or one of its aliases
+func (self *Emitter) collectListeners(event string) []Listener {
 	self.mutex.Lock()
 	defer self.mutex.Unlock()
+	return self.matchListeners(event)
+}
 
+// matchListeners() - the listeners whose pattern matches the event or one of its aliases, the mutex must be held
+func (self *Emitter) matchListeners(event string) []Listener {
 	listeners := make([]Listener, 0)
+	names := self.aliasNames(self.key(event))
+	seen := make(map[uint64]bool)
+	meta := IsMetaEvent(event)
+	add := func(eventPattern, name string, lis []Listener) {
+		wildcard := meta && eventPattern != name
+		var params *regexp.Regexp
+		if !self.exactOnly {
+			params = self.compiled(eventPattern).params
+		}
+		for _, l := range lis {
+			if wildcard && (self.excludeMeta || l.excludeMeta) || l.excepts(self, name) {
+				continue
+			}
+			if !seen[l.id] {
+				seen[l.id] = true
+				l.params = params
+				listeners = append(listeners, l)
+			}
+		}
+	}
 
-	// add the ones that follow pattern
-	for eventPattern, lis := range self.listeners {
-		shouldAdd := false
-
-		// add generic "**" bound listeners
-		shouldAdd = shouldAdd || eventPattern.(string) == "**"
-		// add listener bound on full name event
-		shouldAdd = shouldAdd || eventPattern.(string) == event
-		// add listeners that have matching wildcard pattern
-		shouldAdd = shouldAdd ||
-			(strings.Contains(eventPattern.(string), "*") &&
-				eventMatchPattern([]rune(event), []rune(eventPattern.(string))))
-
-		if shouldAdd {
-			listeners = append(listeners, lis...)
+	if self.exactOnly {
+		// no patterns, the listeners of the names are looked up
+		for _, name := range names {
+			add(name, name, self.listeners[name])
+		}
+	} else {
+		// add the ones that follow pattern
+		for eventPattern, lis := range self.listeners {
+			// generic "**", full name and matching wildcard bound listeners, of the event or its aliases
+			for _, name := range names {
+				if self.compiled(eventPattern.(string)).match(name) {
+					add(eventPattern.(string), name, lis)
+				}
+			}
 		}
 	}
 
-	return listeners
+	// the listeners of the different patterns run in their registration order
+	sort.Slice(listeners, func(i, j int) bool { return listeners[i].id < listeners[j].id })
+	return self.dedupeListeners(listeners)
 }
 
 // ListenersCount() - return the count of listeners in the speicifed event
 func (self *Emitter) ListenersCount(event string) int {
-	return len(self.Listeners(event))
+	return len(self.listenersOf(event))
 }
 
-// EmitSync() - run all listeners of the specified event in synchronous mode
+// EmitSync() - run all listeners of the specified event in synchronous mode, the listeners run are
+// the ones registered when the emit starts: the ones added meanwhile don't run, the ones removed
+// meanwhile still run, except the one-time listeners which run exactly once across concurrent emits
 func (self *Emitter) EmitSync(event string, args ...interface{}) *Emitter {
-	for _, v := range self.Listeners(event) {
-		if v.once {
-			self.removeListenerInternal(event, v.callback, true)
-		}
-		v.callback(args...)
-	}
+	return self.EmitEvent(&Event{Name: event, Args: args})
+}
 
+// EmitEvent() - run all listeners of the event envelope in synchronous mode
+func (self *Emitter) EmitEvent(ev *Event) *Emitter {
+	self.dispatch(ev, false)
 	return self
 }
 
 // EmitAsync() - run all listeners of the specified event in asynchronous mode using goroutines
 func (self *Emitter) EmitAsync(event string, args []interface{}) *Emitter {
-	for _, v := range self.Listeners(event) {
-		if v.once {
-			self.removeListenerInternal(event, v.callback, true)
+	self.dispatch(&Event{Name: event, Args: args}, true)
+	return self
+}
+
+// EmitAsyncPriority() - run all listeners of the specified event in asynchronous mode, their
+// invocations are queued ahead of the ones of lower priority in the emitter's pool, if any
+func (self *Emitter) EmitAsyncPriority(event string, priority int, args ...interface{}) *Emitter {
+	self.dispatch(&Event{Name: event, Args: args, Priority: priority}, true)
+	return self
+}
+
+// delivery - how an emit runs the listeners of its event, admitting it first, how many ran, -1 if it wasn't admitted
+type delivery func(ev *Event, async bool) int
+
+// dispatch() - run all listeners of the event, each in its own goroutine if async
+func (self *Emitter) dispatch(ev *Event, async bool) {
+	self.dispatchWith(ev, async, self.deliverCount)
+}
+
+// dispatchWith() - the path of all the emits: reject the reserved event, tag the tenant's one, hold it while
+// paused, then run it through the delivery, between the emit hooks, and route it between the tenants and their
+// parent; the error of the rejected event
+func (self *Emitter) dispatchWith(ev *Event, async bool, delivery delivery) error {
+	if err := self.checkName(ev.Name, true); err != nil {
+		self.emitMeta("schemaViolation", ev.Name, err)
+		return err
+	}
+	if self.tenancy != nil {
+		ev = self.tenancy.tagged(ev, ev.Name)
+	}
+	if self.hold(ev, async, delivery) {
+		return nil
+	}
+	if self.emitHooks != nil {
+		self.hooked(ev, async, delivery)
+	} else {
+		self.routed(ev, async, delivery)
+	}
+	return nil
+}
+
+// routed() - run the event through the delivery then, once admitted, route it between the tenants and their parent
+func (self *Emitter) routed(ev *Event, async bool, delivery delivery) int {
+	n := delivery(ev, async)
+	if n >= 0 {
+		self.routeTenant(ev, async)
+	}
+	return n
+}
+
+// emitMeta() - run the listeners of the emitter's own meta-event in synchronous mode
+func (self *Emitter) emitMeta(event string, args ...interface{}) {
+	self.deliver(&Event{Name: event, Args: args}, false)
+}
+
+// deliver() - run all the listeners of the event, even a reserved one, whether it was admitted
+func (self *Emitter) deliver(ev *Event, async bool) bool {
+	return self.deliverCount(ev, async) >= 0
+}
+
+// deliverCount() - run all the listeners of the event, even a reserved one, how many ran, -1 if it wasn't admitted
+func (self *Emitter) deliverCount(ev *Event, async bool) int {
+	if !self.admit(ev) {
+		return -1
+	}
+	return self.run(ev, self.listenersOf(ev.Name), async)
+}
+
+// admit() - whether the event can be delivered, unexpired, bounding, validating, sampling, gating and muting
+// it, reporting its deprecation, attributing, journaling and recording it
+func (self *Emitter) admit(ev *Event) bool {
+	if self.expired(ev) || !self.bounded(ev) || !self.validateEmit(ev) || !self.sampled(ev.Name) {
+		return false
+	}
+	if closed, counted := self.gated(ev.Name); closed {
+		if counted {
+			self.reportEmit(ev)
 		}
-		go v.callback(args...)
+		return false
 	}
-	return self
+	if muted, counted := self.muted(ev.Name); muted {
+		if counted {
+			self.reportEmit(ev)
+		}
+		return false
+	}
+	self.deprecation(ev.Name)
+	self.attribute(ev)
+	self.journal(ev)
+	self.record(ev)
+	self.reportEmit(ev)
+	return true
+}
+
+// run() - run the listeners with the event, the one-time ones only if not run yet, how many ran
+func (self *Emitter) run(ev *Event, listeners []Listener, async bool) int {
+	var limits []*concurrencyLimit
+	limited := false
+	limitsOf := func() []*concurrencyLimit {
+		if !limited {
+			limits, limited = self.limitsOf(ev.Name), true
+		}
+		return limits
+	}
+
+	n := 0
+	for _, v := range listeners {
+		if v.once && !self.claimOnce(v) {
+			continue
+		}
+		n++
+		switch {
+		case v.execution == ExecutionAsync:
+			go v.callLimited(ev, limitsOf())
+		case async || v.execution == ExecutionPool:
+			self.spawn(v, ev, limitsOf())
+		default:
+			v.call(ev)
+		}
+	}
+	return n
+}
+
+// spawn() - invoke the listener in the emitter's pool, or in its own goroutine without pool, unless expired meanwhile
+func (self *Emitter) spawn(v Listener, ev *Event, limits []*concurrencyLimit) {
+	self.submitLimited(func() {
+		if !self.expired(ev) {
+			v.call(ev)
+		}
+	}, limits, ev.Priority)
+}
+
+// submit() - run the task in the emitter's pool with the priority, or in its own goroutine without pool
+func (self *Emitter) submit(task func(), priority int) {
+	if self.pool == nil || !self.pool.SubmitPriority(task, priority) {
+		go task()
+		return
+	}
+	self.watchPool()
 }
@@ -3,6 +3,7 @@ package Emitter
 import (
 	"math/rand"
 	"reflect"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -47,6 +48,60 @@ func TestOnce(t *testing.T) {
 	expect(t, 1, counter)
 }
 
+func TestRemoveOnce(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	fn := func(args ...interface{}) {
+		counter++
+	}
+
+	// removed before running
+	emitter.Once("testevent", fn)
+	emitter.RemoveListener("testevent", fn)
+	emitter.EmitSync("testevent")
+	expect(t, 0, counter)
+
+	// removing it once it ran is a no-op
+	emitter.Once("testevent", fn)
+	emitter.EmitSync("testevent")
+	emitter.RemoveListener("testevent", fn)
+	expect(t, 1, counter)
+	expect(t, 0, emitter.ListenersCount("testevent"))
+
+	// on a pattern, it's removed from the pattern
+	emitter.Once("test*", fn)
+	emitter.RemoveListener("test*", fn)
+	emitter.EmitSync("testevent")
+	expect(t, 1, counter)
+}
+
+func TestRemoveWrappedListener(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	fn := func(args ...interface{}) {
+		counter++
+	}
+	wrapper := func(args ...interface{}) {
+		fn(args...)
+		fn(args...)
+	}
+
+	emitter.addListenerInternal("testevent", Listener{callback: fn, once: true}.wrap(wrapper))
+	emitter.addListenerInternal("testevent", Listener{callback: fn}.wrap(wrapper).wrap(wrapper))
+	expect(t, 2, emitter.ListenersCount("testevent"))
+
+	// the original callback identifies the wrapped listeners
+	emitter.RemoveListener("testevent", wrapper)
+	expect(t, 2, emitter.ListenersCount("testevent"))
+	emitter.RemoveListener("testevent", fn)
+	emitter.RemoveListener("testevent", fn)
+	emitter.EmitSync("testevent")
+	expect(t, 0, emitter.ListenersCount("testevent"))
+	expect(t, 0, counter)
+}
+
 func TestWildCardSupport(t *testing.T)  {
 	emitter := Construct()
 
@@ -114,8 +169,8 @@ func TestRandomConcurrentCalls(t *testing.T) {
 
 	wg := sync.WaitGroup{}
 	for j := 0; j < 10; j++ {
+		wg.Add(1)
 		go func() {
-			wg.Add(1)
 			randomCallsFn()
 			wg.Done()
 		}()
@@ -125,6 +180,159 @@ func TestRandomConcurrentCalls(t *testing.T) {
 	expect(t, nil, err)
 }
 
+func TestOnEvent(t *testing.T) {
+	emitter := Construct()
+
+	var got *Event
+	emitter.OnEvent("user.*", func(ev *Event) {
+		got = ev
+	})
+
+	emitter.EmitSync("user.created", "john")
+
+	expect(t, "user.created", got.Name)
+	expect(t, "john", got.Args[0])
+}
+
+func TestSubscriptionRemove(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	handler := func() func(*Event) {
+		return func(ev *Event) {
+			counter++
+		}
+	}
+
+	// both closures share the same code pointer, only the handle tells them apart
+	first := emitter.OnEvent("testevent", handler())
+	emitter.OnEvent("testevent", handler())
+
+	first.Remove()
+	emitter.EmitSync("testevent")
+
+	expect(t, 1, emitter.ListenersCount("testevent"))
+	expect(t, 1, counter)
+}
+
+func TestEmitEvent(t *testing.T) {
+	emitter := Construct()
+
+	origin := ""
+	emitter.OnEvent("testevent", func(ev *Event) {
+		origin = ev.Headers[HeaderOrigin]
+	})
+
+	emitter.EmitEvent(&Event{Name: "testevent", Headers: map[string]string{HeaderOrigin: "node-1"}})
+
+	expect(t, "node-1", origin)
+}
+
+func TestCopyAndMoveListeners(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	sub := emitter.OnEvent("old.event", func(ev *Event) { counter++ })
+
+	emitter.CopyListeners("old.event", "copy.event")
+	expect(t, 1, emitter.ListenersCount("old.event"))
+	expect(t, 1, emitter.ListenersCount("copy.event"))
+
+	emitter.MoveListeners("old.event", "new.event")
+	expect(t, 0, emitter.ListenersCount("old.event"))
+	expect(t, 1, emitter.ListenersCount("new.event"))
+
+	emitter.EmitSync("old.event")
+	emitter.EmitSync("copy.event")
+	emitter.EmitSync("new.event")
+	expect(t, 2, counter)
+
+	// the subscription follows the moved listener, the copy is independent
+	sub.Remove()
+	expect(t, 0, emitter.ListenersCount("new.event"))
+	expect(t, 1, emitter.ListenersCount("copy.event"))
+}
+
+func TestClone(t *testing.T) {
+	template := Construct()
+
+	counter := 0
+	template.On("testevent", func(args ...interface{}) { counter++ })
+
+	clone := template.Clone()
+	clone.On("testevent", func(args ...interface{}) { counter += 10 })
+
+	template.EmitSync("testevent")
+	expect(t, 1, counter)
+	clone.EmitSync("testevent")
+	expect(t, 12, counter)
+	expect(t, 1, template.ListenersCount("testevent"))
+}
+
+func TestInvocationOrder(t *testing.T) {
+	emitter := Construct()
+
+	order := []string{}
+	for _, pattern := range []string{"**", "user.created", "user.*", "*.created", "user.created"} {
+		pattern := pattern
+		emitter.On(pattern, func(args ...interface{}) { order = append(order, pattern) })
+	}
+
+	for i := 0; i < 20; i++ {
+		order = order[:0]
+		emitter.EmitSync("user.created")
+		expect(t, "**,user.created,user.*,*.created,user.created", strings.Join(order, ","))
+	}
+}
+
+func TestEmitSnapshot(t *testing.T) {
+	emitter := Construct()
+
+	var once int32
+	emitter.Once("testevent", func(args ...interface{}) { atomic.AddInt32(&once, 1) })
+	late := 0
+	emitter.On("testevent", func(args ...interface{}) {
+		emitter.On("testevent", func(args ...interface{}) { late++ })
+	})
+
+	emitter.EmitSync("testevent")
+	expect(t, 0, late, "added during the emit")
+	expect(t, int32(1), atomic.LoadInt32(&once))
+
+	wg := sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			emitter.Once("concurrent", func(args ...interface{}) { atomic.AddInt32(&once, 1) })
+		}()
+	}
+	wg.Wait()
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emitter.EmitSync("concurrent")
+			emitter.EmitSync("concurrent")
+		}()
+	}
+	wg.Wait()
+	expect(t, int32(11), atomic.LoadInt32(&once), "each one-time listener ran once")
+}
+
+func TestEventPattern(t *testing.T) {
+	emitter := Construct()
+
+	patterns := []string{}
+	handler := func(ev *Event) { patterns = append(patterns, ev.Pattern) }
+	emitter.OnEvent("user.*", handler)
+	emitter.OnEvent("user.created", handler)
+	emitter.OnPatterns([]string{"order.*"}, func(args ...interface{}) {})
+
+	emitter.EmitSync("user.created")
+	expect(t, "user.*,user.created", strings.Join(patterns, ","))
+}
+
 func expect(t *testing.T, a interface{}, b interface{}, desc ...string) {
 	if a != b {
 		t.Errorf("%v+ -> Expected %v (type %v) - Got %v (type %v)", desc, a, reflect.TypeOf(a), b, reflect.TypeOf(b))
@@ -0,0 +1,77 @@
+package Emitter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HealthPolicy - the thresholds Healthy checks the emitter against, a zero threshold isn't checked
+type HealthPolicy struct {
+	// MaxPending - the tasks the pool may have pending while all its goroutines are busy
+	MaxPending int
+	// StuckAfter - how long the oldest pending task of the pool may wait for a goroutine
+	StuckAfter time.Duration
+	// FailureBudget - the share of the invocations of a listener allowed to panic, once it ran MinCalls times
+	FailureBudget float64
+	MinCalls      uint64
+}
+
+// DefaultHealthPolicy - the policy of the emitters without one
+var DefaultHealthPolicy = HealthPolicy{
+	MaxPending:    10000,
+	StuckAfter:    30 * time.Second,
+	FailureBudget: 0.05,
+	MinCalls:      20,
+}
+
+// HealthError - the invariants the emitter breaks
+type HealthError struct {
+	Problems []string
+}
+
+func (self *HealthError) Error() string {
+	return "emitter: unhealthy, " + strings.Join(self.Problems, ", ")
+}
+
+// WithHealthPolicy() - check the emitter's health against the policy instead of DefaultHealthPolicy
+func WithHealthPolicy(policy HealthPolicy) Option {
+	return func(e *Emitter) {
+		e.health = &policy
+	}
+}
+
+// Healthy() - check that the pool isn't closed, saturated or stuck and that no listener exceeds its
+// failure budget, a *HealthError listing the problems if not, for the service health endpoints
+func (self *Emitter) Healthy() error {
+	policy := DefaultHealthPolicy
+	if self.health != nil {
+		policy = *self.health
+	}
+
+	problems := []string{}
+	if pool := self.pool; pool != nil {
+		if pool.Closed() {
+			problems = append(problems, "the pool is closed")
+		}
+		if pending := pool.Pending(); policy.MaxPending > 0 && pending > policy.MaxPending && pool.Busy() == pool.Size() {
+			problems = append(problems, fmt.Sprintf("the pool is saturated with %d pending tasks", pending))
+		}
+		if waiting := pool.Waiting(); policy.StuckAfter > 0 && waiting > policy.StuckAfter {
+			problems = append(problems, fmt.Sprintf("a pool task is waiting for %v", waiting.Round(time.Millisecond)))
+		}
+	}
+
+	if policy.FailureBudget > 0 {
+		for _, stats := range self.ListenerStats() {
+			if stats.Calls >= policy.MinCalls && stats.Calls > 0 && float64(stats.Panics)/float64(stats.Calls) > policy.FailureBudget {
+				problems = append(problems, fmt.Sprintf("listener #%d of %q panicked %d times out of %d", stats.ID, stats.Event, stats.Panics, stats.Calls))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return &HealthError{problems}
+	}
+	return nil
+}
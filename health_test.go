@@ -0,0 +1,65 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHealthyFailureBudget(t *testing.T) {
+	emitter := New(WithHealthPolicy(HealthPolicy{FailureBudget: 0.25, MinCalls: 4}))
+	expect(t, nil, emitter.Healthy())
+
+	calls := 0
+	sub := emitter.Subscribe("job", func(args ...interface{}) {
+		if calls++; calls%2 == 0 {
+			panic("failed")
+		}
+	})
+	for i := 0; i < 3; i++ {
+		func() {
+			defer func() { recover() }()
+			emitter.EmitSync("job")
+		}()
+	}
+	expect(t, uint64(1), sub.Stats().Panics)
+	expect(t, nil, emitter.Healthy(), "not enough calls yet")
+
+	func() {
+		defer func() { recover() }()
+		emitter.EmitSync("job")
+	}()
+	err := emitter.Healthy()
+	expect(t, true, err != nil)
+	expect(t, 1, len(err.(*HealthError).Problems))
+	expect(t, true, strings.Contains(err.Error(), `"job" panicked 2 times out of 4`))
+
+	sub.Remove()
+	expect(t, nil, emitter.Healthy(), "the removed listeners aren't checked")
+}
+
+func TestHealthyPool(t *testing.T) {
+	pool := NewPool(1)
+	emitter := New(WithPool(pool), WithHealthPolicy(HealthPolicy{MaxPending: 1, StuckAfter: 20 * time.Millisecond}))
+
+	release := make(chan bool)
+	emitter.On("job", func(args ...interface{}) { <-release })
+	for i := 0; i < 3; i++ {
+		emitter.EmitAsync("job", nil)
+	}
+	for pool.Busy() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(30 * time.Millisecond)
+
+	err := emitter.Healthy()
+	expect(t, true, err != nil)
+	problems := err.(*HealthError).Problems
+	expect(t, 2, len(problems))
+	expect(t, true, strings.Contains(problems[0], "saturated with 2 pending"))
+	expect(t, true, strings.Contains(problems[1], "waiting for"))
+
+	close(release)
+	pool.Close()
+	expect(t, "emitter: unhealthy, the pool is closed", emitter.Healthy().Error())
+}
@@ -0,0 +1,92 @@
+package Emitter
+
+import (
+	"time"
+)
+
+// OnListenerAdded() - run the hook whenever a listener is registered, an alternative to
+// listening on the "newListener" meta-event that wildcard listeners receive too
+func (self *Emitter) OnListenerAdded(hook func(event string, l Listener)) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.addedHooks = append(self.addedHooks, hook)
+	return self
+}
+
+// OnListenerRemoved() - run the hook whenever a listener is removed, one-time listeners
+// removed once run excepted, an alternative to listening on the "removeListener" meta-event
+func (self *Emitter) OnListenerRemoved(hook func(event string, l Listener)) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.removedHooks = append(self.removedHooks, hook)
+	return self
+}
+
+// Event() - the event (pattern) the listener is registered on
+func (self Listener) Event() string {
+	return self.event
+}
+
+// Once() - whether the listener runs once
+func (self Listener) Once() bool {
+	return self.once
+}
+
+// listenerAdded() - run the hooks and raise the "newListener" meta-event of the registered listener
+func (self *Emitter) listenerAdded(event string, l Listener) {
+	self.mutex.Lock()
+	hooks := self.addedHooks
+	self.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook(event, l)
+	}
+	self.emitMeta("newListener", []interface{}{event, l.function()})
+}
+
+// listenerRemoved() - run the hooks and raise the "removeListener" meta-event of the removed listener
+func (self *Emitter) listenerRemoved(event string, l Listener) {
+	self.mutex.Lock()
+	hooks := self.removedHooks
+	self.mutex.Unlock()
+
+	for _, hook := range hooks {
+		hook(event, l)
+	}
+	self.emitMeta("removeListener", []interface{}{event, l.function()})
+}
+
+// emitHooks - the instrumentation of the emits, see WithEmitHooks
+type emitHooks struct {
+	before func(event string, args []interface{})
+	after  func(event string, d time.Duration, n int)
+}
+
+// WithEmitHooks() - call before ahead of every emit, whatever its variant (EmitSync, EmitFirst, EmitWith ...),
+// and after once its listeners ran, with how long they took and how many ran (0 if the emit was rejected),
+// the async ones being only started; a lightweight instrumentation point, i.e for tracing, either hook may be nil
+func WithEmitHooks(before func(event string, args []interface{}), after func(event string, d time.Duration, n int)) Option {
+	return func(e *Emitter) {
+		e.emitHooks = &emitHooks{before, after}
+	}
+}
+
+// hooked() - run the event through the delivery between the emit hooks
+func (self *Emitter) hooked(ev *Event, async bool, delivery delivery) {
+	hooks := self.emitHooks
+	if hooks.before != nil {
+		hooks.before(ev.Name, ev.Args)
+	}
+	clock := self.Clock()
+	start := clock.Now()
+
+	n := self.routed(ev, async, delivery)
+	if hooks.after != nil {
+		if n < 0 {
+			n = 0
+		}
+		hooks.after(ev.Name, clock.Now().Sub(start), n)
+	}
+}
@@ -0,0 +1,61 @@
+package Emitter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestListenerHooks(t *testing.T) {
+	emitter := Construct()
+
+	added, removed := []string{}, []string{}
+	emitter.OnListenerAdded(func(event string, l Listener) { added = append(added, event) })
+	emitter.OnListenerRemoved(func(event string, l Listener) { removed = append(removed, l.Event()) })
+
+	callback := func(args ...interface{}) {}
+	emitter.Once("a", callback)
+	emitter.OnEvents([]string{"b", "c"}, callback).Remove()
+	emitter.EmitSync("a")
+
+	expect(t, 3, len(added))
+	expect(t, 2, len(removed), "the once listener ran, it isn't reported")
+	expect(t, "b", removed[0])
+}
+
+func TestWithEmitHooks(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	before, after := []string{}, []string{}
+	emitter := New(WithClock(clock), WithEmitHooks(
+		func(event string, args []interface{}) { before = append(before, fmt.Sprint(event, args)) },
+		func(event string, d time.Duration, n int) { after = append(after, fmt.Sprint(event, " ", d, " ", n)) },
+	))
+	emitter.On("user.*", func(args ...interface{}) { clock.Advance(time.Second) })
+	emitter.On("user.created", func(args ...interface{}) {})
+	emitter.SetValidator("order.created", func(args []interface{}) error { return ErrTooManyArgs })
+
+	emitter.EmitSync("user.created", 42)
+	emitter.EmitSync("order.created", 1)
+	emitter.EmitSync("order.paid")
+	expect(t, "user.created[42],order.created[1],order.paid[]", strings.Join(before, ","))
+	expect(t, "user.created 1s 2,order.created 0s 0,order.paid 0s 0", strings.Join(after, ","))
+}
+
+func TestEmitHooksVariants(t *testing.T) {
+	after := []string{}
+	emitter := New(WithEmitHooks(nil, func(event string, d time.Duration, n int) { after = append(after, fmt.Sprint(event, " ", n)) }))
+	emitter.On("job", func(args ...interface{}) {})
+	emitter.OnHandler("job", func(args ...interface{}) bool { return true })
+
+	emitter.EmitBalanced("job")
+	emitter.EmitFirst("job")
+	emitter.EmitMulti([]string{"job", "task"})
+	emitter.EmitLocal("job")
+	emitter.EmitWith("job", nil, MaxListeners(1))
+	emitter.EmitReduce("job", nil)
+	emitter.EmitSyncTimeout("job", time.Second)
+	emitter.EmitPattern("job")
+	emitter.EmitAsyncResult("job").Wait()
+	expect(t, "job 2,job 2,job 2,task 0,job 2,job 1,job 2,job 2,job 2,job 2", strings.Join(after, ","))
+}
@@ -0,0 +1,25 @@
+// Package bridge holds what the transport bridges share besides their envelope codec.
+package bridge
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// NewID() - a random origin id, or a time based one if the random source fails
+func NewID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
+
+// Report() - pass the error to the bridge's OnError, if both are set
+func Report(onError func(err error), err error) {
+	if err != nil && onError != nil {
+		onError(err)
+	}
+}
@@ -0,0 +1,25 @@
+package bridge
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewID(t *testing.T) {
+	a, b := NewID(), NewID()
+	if len(a) != 16 || a == b {
+		t.Errorf("Expected distinct 16 hex digits ids - Got %q and %q", a, b)
+	}
+}
+
+func TestReport(t *testing.T) {
+	reported := []error{}
+	onError := func(err error) { reported = append(reported, err) }
+
+	Report(onError, nil)
+	Report(nil, errors.New("ignored"))
+	Report(onError, errors.New("failed"))
+	if len(reported) != 1 || reported[0].Error() != "failed" {
+		t.Errorf("Expected the failure only - Got %v", reported)
+	}
+}
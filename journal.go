@@ -0,0 +1,134 @@
+package Emitter
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// JournalRecord - an emitted event as written to a journal
+type JournalRecord struct {
+	Time    time.Time         `json:"time"`
+	Name    string            `json:"name"`
+	Args    []interface{}     `json:"args"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// Journal - an append-only log of the events emitted by an emitter, one json record per line,
+// the args are json encoded so they are replayed as json decodes them
+type Journal struct {
+	// OnError - called with the errors of writing the records, if set
+	OnError func(err error)
+
+	emitter *Emitter
+	w       io.Writer
+	closer  io.Closer
+	mutex   *sync.Mutex
+}
+
+// Journal() - append the events emitted from now on to the writer, the emitter's meta-events excepted
+func (self *Emitter) Journal(w io.Writer) *Journal {
+	journal := &Journal{emitter: self, w: w, mutex: &sync.Mutex{}}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.journals = append(self.journals, journal)
+	return journal
+}
+
+// OpenJournal() - append the events emitted from now on to the file, created if needed
+func (self *Emitter) OpenJournal(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	journal := self.Journal(file)
+	journal.closer = file
+	return journal, nil
+}
+
+// Close() - stop journaling, the journal's file is closed if it was opened by OpenJournal
+func (self *Journal) Close() error {
+	e := self.emitter
+	e.mutex.Lock()
+	for i, journal := range e.journals {
+		if journal == self {
+			e.journals = append(e.journals[:i:i], e.journals[i+1:]...)
+			break
+		}
+	}
+	e.mutex.Unlock()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closer != nil {
+		closer := self.closer
+		self.closer = nil
+		return closer.Close()
+	}
+	return nil
+}
+
+// record() - append the event to the journal
+func (self *Journal) record(ev *Event) {
+	data, err := json.Marshal(&JournalRecord{Time: self.emitter.Clock().Now(), Name: ev.Name, Args: ev.Args, Headers: ev.Headers})
+	if err == nil {
+		self.mutex.Lock()
+		_, err = self.w.Write(append(data, '\n'))
+		self.mutex.Unlock()
+	}
+	if err != nil && self.OnError != nil {
+		self.OnError(err)
+	}
+}
+
+// journal() - append the event to the emitter's journals
+func (self *Emitter) journal(ev *Event) {
+	if IsMetaEvent(ev.Name) {
+		return
+	}
+
+	self.mutex.Lock()
+	journals := self.journals
+	self.mutex.Unlock()
+
+	for _, journal := range journals {
+		journal.record(ev)
+	}
+}
+
+// ReadJournal() - run fn with each record of the journal, in their order, until it returns an error
+func ReadJournal(r io.Reader, fn func(record *JournalRecord) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 64*1024*1024)
+	for scanner.Scan() {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		record := &JournalRecord{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// Replay() - emit the events of the journal, in their order and in synchronous mode, i.e to
+// recover the state of a crashed service; the count of replayed events is returned
+func (self *Emitter) Replay(r io.Reader) (int, error) {
+	count := 0
+	err := ReadJournal(r, func(record *JournalRecord) error {
+		self.EmitEvent(&Event{Name: record.Name, Args: record.Args, Headers: record.Headers})
+		count++
+		return nil
+	})
+	return count, err
+}
@@ -0,0 +1,61 @@
+package Emitter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestJournalReplay(t *testing.T) {
+	emitter := Construct()
+	buf := &bytes.Buffer{}
+	journal := emitter.Journal(buf)
+
+	emitter.On("user.created", func(args ...interface{}) {})
+	emitter.EmitSync("user.created", "john", 42)
+	emitter.EmitMulti([]string{"a", "b"})
+	journal.Close()
+	emitter.EmitSync("user.created", "jane")
+
+	replayed := Construct()
+	names := []string{}
+	replayed.OnEvent("**", func(ev *Event) {
+		if !IsMetaEvent(ev.Name) {
+			names = append(names, ev.Name)
+		}
+	})
+	count, err := replayed.Replay(bytes.NewReader(buf.Bytes()))
+	expect(t, nil, err)
+	expect(t, 3, count)
+	expect(t, "user.created", names[0])
+	expect(t, "b", names[2])
+
+	var args []interface{}
+	ReadJournal(bytes.NewReader(buf.Bytes()), func(record *JournalRecord) error {
+		if args == nil {
+			args = record.Args
+		}
+		return nil
+	})
+	expect(t, "john", args[0])
+	expect(t, 42.0, args[1])
+}
+
+func TestOpenJournal(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "journal")
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "events.log")
+
+	emitter := Construct()
+	journal, err := emitter.OpenJournal(path)
+	expect(t, nil, err)
+	emitter.EmitSync("a")
+	expect(t, nil, journal.Close())
+
+	file, _ := os.Open(path)
+	defer file.Close()
+	count, _ := Construct().Replay(file)
+	expect(t, 1, count)
+}
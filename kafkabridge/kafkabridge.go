@@ -0,0 +1,238 @@
+// Package kafkabridge publishes selected events of an emitter to Kafka topics and
+// consumes topics back into the emitter.
+//
+// The bridge doesn't depend on a Kafka client, adapt your producer/consumer
+// (sarama, kafka-go, confluent-kafka-go ...) to the Producer/Consumer interfaces.
+// The envelope headers travel as Kafka headers and the message key is extracted
+// from the envelope's "key" header by default.
+package kafkabridge
+
+import (
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+	"github.com/moleculer-go/goemitter/codec"
+	"github.com/moleculer-go/goemitter/internal/bridge"
+)
+
+// HeaderKey - the default envelope header holding the message key
+const HeaderKey = "key"
+
+// Message - a Kafka message
+type Message struct {
+	Topic   string
+	Key     []byte
+	Value   []byte
+	Headers map[string]string
+}
+
+// Producer - produces the messages to Kafka
+type Producer interface {
+	Produce(msg Message) error
+}
+
+// Consumer - consumes the messages of the subscribed topics
+type Consumer interface {
+	// Consume - block until the next message, an error stops consuming
+	Consume() (Message, error)
+	Close() error
+}
+
+// Bridge - routes the events between an emitter and Kafka
+type Bridge struct {
+	// Codec - the message value serialization, codec.JSON typed by the emitter's schemas by default
+	Codec codec.Codec
+	// Key - extract the message key of the event, defaults to its "key" header
+	Key func(ev *Emitter.Event) []byte
+	// OnError - called with the errors of producing/consuming/decoding, if set
+	OnError func(err error)
+	// Relay - also produce the events received from other bridges, through MaxHops bridges at most
+	// and never back through a bridge they went through, see Emitter.Forwards
+	Relay   bool
+	MaxHops int
+
+	id        string
+	emitter   *Emitter.Emitter
+	producer  Producer
+	routes    []route
+	listener  *Emitter.Subscription
+	consumers []Consumer
+	closed    bool
+	wg        *sync.WaitGroup
+	mutex     *sync.Mutex
+}
+
+// route - the events matching the pattern are produced to the topic
+type route struct {
+	pattern string
+	topic   string
+}
+
+// New() - create a new bridge producing with the specified producer (nil for a consume only bridge)
+func New(e *Emitter.Emitter, producer Producer) *Bridge {
+	return &Bridge{
+		Codec:    codec.Typed(e, codec.JSON),
+		Key:      HeaderKeyOf(HeaderKey),
+		MaxHops:  Emitter.DefaultMaxHops,
+		id:       bridge.NewID(),
+		emitter:  e,
+		producer: producer,
+		wg:       &sync.WaitGroup{},
+		mutex:    &sync.Mutex{},
+	}
+}
+
+// HeaderKeyOf() - a Key extractor returning the specified envelope header
+func HeaderKeyOf(header string) func(ev *Emitter.Event) []byte {
+	return func(ev *Emitter.Event) []byte {
+		if key, ok := ev.Headers[header]; ok {
+			return []byte(key)
+		}
+		return nil
+	}
+}
+
+// ID() - the origin id stamped on the messages produced by the bridge
+func (self *Bridge) ID() string {
+	return self.id
+}
+
+// Publish() - produce the local events matching the pattern to the topic
+func (self *Bridge) Publish(pattern, topic string) *Bridge {
+	self.mutex.Lock()
+	self.routes = append(self.routes, route{pattern, topic})
+	listening := self.listener != nil
+	self.mutex.Unlock()
+
+	if !listening {
+		listener := self.emitter.OnEvent("**", self.produce).Bridge()
+		self.mutex.Lock()
+		self.listener = listener
+		self.mutex.Unlock()
+	}
+	return self
+}
+
+// Consume() - emit the messages of the consumer locally until it fails or the bridge is closed
+func (self *Bridge) Consume(consumer Consumer) {
+	self.mutex.Lock()
+	self.consumers = append(self.consumers, consumer)
+	self.mutex.Unlock()
+
+	self.wg.Add(1)
+	go func() {
+		defer self.wg.Done()
+		for {
+			msg, err := consumer.Consume()
+			if err != nil {
+				if !self.isClosed() {
+					bridge.Report(self.OnError, err)
+				}
+				return
+			}
+			self.emit(msg)
+		}
+	}()
+}
+
+// Close() - stop producing and close the consumers
+func (self *Bridge) Close() error {
+	self.mutex.Lock()
+	listener, consumers := self.listener, self.consumers
+	self.listener, self.consumers, self.routes = nil, nil, nil
+	self.closed = true
+	self.mutex.Unlock()
+
+	if listener != nil {
+		listener.Remove()
+	}
+
+	var err error
+	for _, consumer := range consumers {
+		if e := consumer.Close(); e != nil && err == nil {
+			err = e
+		}
+	}
+	self.wg.Wait()
+	return err
+}
+
+// produce() - produce the locally originated event to the topics routing it
+func (self *Bridge) produce(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) || !Emitter.Forwards(ev, self.id, self.Relay, self.MaxHops) || self.producer == nil {
+		return
+	}
+
+	topics := self.topics(ev.Name)
+	if len(topics) == 0 {
+		return
+	}
+
+	out := ev.Forwarded(self.id)
+	headers := out.Headers
+	value, err := self.Codec.Marshal(&Emitter.Event{Name: ev.Name, Args: ev.Args, Headers: headers})
+	if err != nil {
+		bridge.Report(self.OnError, err)
+		return
+	}
+
+	key := self.Key(ev)
+	for _, topic := range topics {
+		bridge.Report(self.OnError, self.producer.Produce(Message{topic, key, value, headers}))
+	}
+}
+
+// emit() - emit a consumed message locally, unless the bridge produced it
+func (self *Bridge) emit(msg Message) {
+	ev, err := self.Codec.Unmarshal(msg.Value)
+	if err != nil {
+		bridge.Report(self.OnError, err)
+		return
+	}
+
+	// the kafka headers win over the ones of the value, they may have been set by the producer
+	if ev.Headers == nil {
+		ev.Headers = map[string]string{}
+	}
+	for k, v := range msg.Headers {
+		ev.Headers[k] = v
+	}
+	if ev.Name == "" {
+		ev.Name = msg.Topic
+	}
+	if ev.Visited(self.id) {
+		return
+	}
+	if ev.Headers[Emitter.HeaderOrigin] == "" {
+		// produced by a foreign producer, mark it remote so it isn't produced back
+		ev.Headers[Emitter.HeaderOrigin] = msg.Topic
+	}
+	if _, ok := ev.Headers[HeaderKey]; !ok && msg.Key != nil {
+		ev.Headers[HeaderKey] = string(msg.Key)
+	}
+	ev.Received(self.id)
+	self.emitter.EmitEvent(ev)
+}
+
+// topics() - the topics routing the event
+func (self *Bridge) topics(event string) []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	topics := []string{}
+	seen := map[string]bool{}
+	for _, r := range self.routes {
+		if !seen[r.topic] && Emitter.Match(r.pattern, event) {
+			seen[r.topic] = true
+			topics = append(topics, r.topic)
+		}
+	}
+	return topics
+}
+
+// isClosed() - whether the bridge is closed, consumers failing then are just closed
+func (self *Bridge) isClosed() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.closed
+}
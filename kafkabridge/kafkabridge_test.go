@@ -0,0 +1,96 @@
+package kafkabridge
+
+import (
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// fakeKafka - an in memory topic log, consumers read the messages of their topics
+type fakeKafka struct {
+	messages []Message
+	mutex    sync.Mutex
+}
+
+func (self *fakeKafka) Produce(msg Message) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.messages = append(self.messages, msg)
+	return nil
+}
+
+type fakeConsumer struct {
+	messages chan Message
+	once     sync.Once
+}
+
+func (self *fakeConsumer) Consume() (Message, error) {
+	msg, ok := <-self.messages
+	if !ok {
+		return Message{}, io.EOF
+	}
+	return msg, nil
+}
+
+func (self *fakeConsumer) Close() error {
+	self.once.Do(func() { close(self.messages) })
+	return nil
+}
+
+func TestProduce(t *testing.T) {
+	kafka := &fakeKafka{}
+	emitter := Emitter.Construct()
+	bridge := New(emitter, kafka).Publish("user.*", "users").Publish("**", "audit")
+	defer bridge.Close()
+
+	emitter.EmitEvent(&Emitter.Event{Name: "user.created", Args: []interface{}{"john"}, Headers: map[string]string{"key": "user-1"}})
+	emitter.EmitSync("account.created")
+
+	expect(t, 3, len(kafka.messages))
+	expect(t, "users", kafka.messages[0].Topic)
+	expect(t, "user-1", string(kafka.messages[0].Key))
+	expect(t, bridge.ID(), kafka.messages[0].Headers[Emitter.HeaderOrigin])
+	expect(t, "audit", kafka.messages[1].Topic)
+	expect(t, "audit", kafka.messages[2].Topic)
+}
+
+func TestConsume(t *testing.T) {
+	kafka := &fakeKafka{}
+	e1, e2 := Emitter.Construct(), Emitter.Construct()
+	producer := New(e1, kafka).Publish("user.*", "users")
+	defer producer.Close()
+
+	consumer := &fakeConsumer{messages: make(chan Message, 10)}
+	bridge := New(e2, kafka).Publish("**", "audit")
+	bridge.Consume(consumer)
+
+	received := make(chan *Emitter.Event, 1)
+	e2.OnEvent("user.created", func(ev *Emitter.Event) {
+		received <- ev
+	})
+
+	e1.EmitEvent(&Emitter.Event{Name: "user.created", Args: []interface{}{"john"}, Headers: map[string]string{"key": "user-1"}})
+	consumer.messages <- kafka.messages[0]
+
+	select {
+	case ev := <-received:
+		expect(t, "john", ev.Args[0])
+		expect(t, "user-1", ev.Headers[HeaderKey])
+	case <-time.After(time.Second):
+		t.Fatal("message not consumed")
+	}
+
+	bridge.Close()
+	// consumed events are remote, they aren't produced again to the audit topic
+	expect(t, 1, len(kafka.messages))
+	expect(t, 1, e2.ListenersCount("user.created"))
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
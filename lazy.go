@@ -0,0 +1,19 @@
+package Emitter
+
+import (
+	"reflect"
+	"sync"
+)
+
+// OnLazy() - register a new listener built by the factory on the first delivery of the event, to defer
+// an expensive initialization until the event actually occurs; the factory runs once, the concurrent
+// deliveries waiting for it
+func (self *Emitter) OnLazy(event string, factory func() func(...interface{})) *Subscription {
+	once := &sync.Once{}
+	var callback func(...interface{})
+	lazy := func(args ...interface{}) {
+		once.Do(func() { callback = factory() })
+		callback(args...)
+	}
+	return self.addListenerInternal(event, Listener{callback: lazy, origin: reflect.ValueOf(factory).Pointer()})
+}
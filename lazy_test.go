@@ -0,0 +1,41 @@
+package Emitter
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestOnLazy(t *testing.T) {
+	emitter := New()
+	built, calls := 0, 0
+	mutex := &sync.Mutex{}
+	sub := emitter.OnLazy("user.created", func() func(...interface{}) {
+		built++
+		return func(args ...interface{}) {
+			mutex.Lock()
+			calls += args[0].(int)
+			mutex.Unlock()
+		}
+	})
+	expect(t, 0, built, "the listener is built on the first delivery")
+
+	emitter.EmitSync("user.created", 1)
+	expect(t, 1, built)
+	expect(t, 1, calls)
+
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			emitter.EmitSync("user.created", 1)
+		}()
+	}
+	wg.Wait()
+	expect(t, 1, built, "the listener is built once")
+	expect(t, 11, calls)
+
+	sub.Remove()
+	emitter.EmitSync("user.created", 1)
+	expect(t, 11, calls)
+}
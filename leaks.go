@@ -0,0 +1,93 @@
+package Emitter
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Leak - a listener still registered past the detector's max age, or after the emitter's destruction
+type Leak struct {
+	Event string
+	// Site - where the listener was registered, "file:line function"
+	Site string
+	Age  time.Duration
+}
+
+// leakDetector - the configuration of the leak detection
+type leakDetector struct {
+	maxAge     time.Duration
+	destructed bool
+}
+
+// DetectLeaks() - record the call site of the listeners registered from now on, to report the ones
+// older than maxAge (any age if 0) or still registered once the emitter is destructed, it has a
+// small cost on each registration so it's meant for development and tests
+func (self *Emitter) DetectLeaks(maxAge time.Duration) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.leaks = &leakDetector{maxAge: maxAge}
+	return self
+}
+
+// Leaks() - the listeners registered while detecting leaks that are older than the max age,
+// or all of them once the emitter is destructed, the oldest first
+func (self *Emitter) Leaks() []Leak {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	leaks := []Leak{}
+	if self.leaks == nil {
+		return leaks
+	}
+
+	now := self.Clock().Now()
+	for _, listeners := range self.listeners {
+		for _, l := range listeners {
+			if l.site == "" {
+				continue
+			}
+			if age := now.Sub(l.created); self.leaks.destructed || age >= self.leaks.maxAge {
+				leaks = append(leaks, Leak{Event: l.event, Site: l.site, Age: age})
+			}
+		}
+	}
+	sort.Slice(leaks, func(i, j int) bool { return leaks[i].Age > leaks[j].Age })
+	return leaks
+}
+
+// ReportLeaks() - print the leaks with where their listeners were registered, return their count
+func (self *Emitter) ReportLeaks(w io.Writer) int {
+	leaks := self.Leaks()
+	for _, leak := range leaks {
+		fmt.Fprintf(w, "emitter: listener of %q registered %v ago at %s\n", leak.Event, leak.Age.Round(time.Millisecond), leak.Site)
+	}
+	return len(leaks)
+}
+
+// track() - count the invocations of the listener, and record its registration time and call site
+// if detecting leaks, the mutex must be held
+func (self *Emitter) track(listener *Listener) {
+	listener.stats = &invocations{emitter: self}
+	if self.leaks == nil {
+		return
+	}
+	listener.created = self.Clock().Now()
+	listener.site = callSite()
+}
+
+// callSite() - the first caller outside of the emitter's methods
+func callSite() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "goemitter.(*") || !more {
+			return fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package Emitter
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLeaks(t *testing.T) {
+	emitter := Construct()
+	emitter.On("untracked", func(args ...interface{}) {})
+	emitter.DetectLeaks(time.Hour)
+
+	sub := emitter.OnEvent("removed", func(ev *Event) {})
+	emitter.On("forgotten", func(args ...interface{}) {})
+	sub.Remove()
+	expect(t, 0, len(emitter.Leaks()), "nothing is old enough")
+
+	emitter.Destruct()
+	leaks := emitter.Leaks()
+	expect(t, 1, len(leaks))
+	expect(t, "forgotten", leaks[0].Event)
+	expect(t, true, strings.Contains(leaks[0].Site, "leaks_test.go"), leaks[0].Site)
+
+	out := &bytes.Buffer{}
+	expect(t, 1, emitter.ReportLeaks(out))
+	expect(t, true, strings.Contains(out.String(), "TestLeaks"), out.String())
+}
@@ -0,0 +1,114 @@
+package Emitter
+
+import (
+	"sync/atomic"
+)
+
+// LifecyclePrefix - the prefix of the lifecycle meta-events, only their names are reserved: the user code
+// can't emit them but can emit any other event of the prefix
+const LifecyclePrefix = "emitter."
+
+// the lifecycle meta-events of the emitters, run in synchronous mode with the emitter as first arg
+const (
+	// EventConstructed - the emitter is created, only the listeners registered by its options get it
+	EventConstructed = "emitter.constructed"
+	// EventPaused - the emitter holds the emits from now on, see Pause
+	EventPaused = "emitter.paused"
+	// EventResumed - the emitter delivers the emits again, the held ones are delivered right after
+	EventResumed = "emitter.resumed"
+	// EventClosing - the emitter is about to close, its listeners still get the events of its cleanup
+	EventClosing = "emitter.closing"
+	// EventClosed - the emitter is closed
+	EventClosed = "emitter.closed"
+	// EventPoolSaturated - all the goroutines of the emitter's pool are busy and its invocations queue,
+	// with the count of pending tasks as second arg; emitted again once the queue drained
+	EventPoolSaturated = "emitter.pool.saturated"
+)
+
+// IsLifecycleEvent() - whether the event is one of the lifecycle meta-events
+func IsLifecycleEvent(event string) bool {
+	switch event {
+	case EventConstructed, EventPaused, EventResumed, EventClosing, EventClosed, EventPoolSaturated:
+		return true
+	}
+	return false
+}
+
+// WithListener() - register the listener while creating the emitter, so it gets EventConstructed too
+func WithListener(event string, callback func(...interface{})) Option {
+	return func(e *Emitter) {
+		e.On(event, callback)
+	}
+}
+
+// held - an emit held while the emitter is paused
+type held struct {
+	ev       *Event
+	async    bool
+	delivery delivery
+}
+
+// Pause() - hold the emits (EmitSync, EmitAsync, EmitFirst, EmitWith ...) instead of delivering them, until
+// resumed: the emits returning a result return the one of no listener run, i.e EmitFirst returns false
+func (self *Emitter) Pause() *Emitter {
+	self.mutex.Lock()
+	paused := self.paused
+	self.paused = true
+	self.mutex.Unlock()
+
+	if !paused {
+		self.emitMeta(EventPaused, self)
+	}
+	return self
+}
+
+// Resume() - deliver the emits again, the held ones first in their order, but the expired ones, see EmitTTL
+func (self *Emitter) Resume() *Emitter {
+	self.mutex.Lock()
+	if !self.paused {
+		self.mutex.Unlock()
+		return self
+	}
+	self.paused = false
+	pending := self.held
+	self.held = nil
+	self.mutex.Unlock()
+
+	self.emitMeta(EventResumed, self)
+	for _, h := range pending {
+		self.dispatchWith(h.ev, h.async, h.delivery)
+	}
+	return self
+}
+
+// Paused() - whether the emitter holds the emits
+func (self *Emitter) Paused() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.paused
+}
+
+// hold() - hold the emit and its delivery if the emitter is paused
+func (self *Emitter) hold(ev *Event, async bool, delivery delivery) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if !self.paused {
+		return false
+	}
+	self.held = append(self.held, held{ev, async, delivery})
+	return true
+}
+
+// watchPool() - emit EventPoolSaturated when the pool's goroutines are all busy and tasks queue,
+// once until its queue drained
+func (self *Emitter) watchPool() {
+	pending := self.pool.saturation()
+	if pending == 0 {
+		atomic.StoreInt32(&self.saturated, 0)
+		return
+	}
+	if atomic.CompareAndSwapInt32(&self.saturated, 0, 1) {
+		self.emitMeta(EventPoolSaturated, self, pending)
+	}
+}
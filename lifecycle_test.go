@@ -0,0 +1,111 @@
+package Emitter
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLifecycleEvents(t *testing.T) {
+	lifecycle := []string{}
+	record := func(event string) func(...interface{}) {
+		return func(args ...interface{}) {
+			lifecycle = append(lifecycle, event)
+		}
+	}
+	emitter := New(WithListener(EventConstructed, record("constructed")))
+	emitter.On(EventPaused, record("paused")).On(EventResumed, record("resumed"))
+	expect(t, 1, len(lifecycle))
+	expect(t, "constructed", lifecycle[0])
+
+	delivered := []interface{}{}
+	emitter.On("job", func(args ...interface{}) { delivered = append(delivered, args[0]) })
+
+	emitter.Pause()
+	emitter.Pause()
+	expect(t, true, emitter.Paused())
+	emitter.EmitSync("job", 1)
+	emitter.EmitSync("job", 2)
+	expect(t, 0, len(delivered), "held while paused")
+
+	emitter.Resume()
+	expect(t, false, emitter.Paused())
+	expect(t, 2, len(delivered))
+	expect(t, 1, delivered[0], "in their order")
+	expect(t, 3, len(lifecycle), "paused and resumed once")
+	expect(t, "resumed", lifecycle[2])
+
+	// the lifecycle names are reserved, not the rest of their prefix
+	violations := 0
+	emitter.On("schemaViolation", func(args ...interface{}) { violations++ })
+	emitter.EmitSync(EventClosed)
+	expect(t, 1, violations)
+	expect(t, nil, emitter.CheckEmit("emitter.started"))
+	expect(t, true, IsMetaEvent(EventPoolSaturated))
+	expect(t, false, IsMetaEvent("emitter.started"))
+
+	started := 0
+	emitter.On("emitter.started", func(args ...interface{}) { started++ })
+	emitter.EmitSync("emitter.started")
+	expect(t, 1, started, "the user events of the prefix are delivered")
+
+	closed := []string{}
+	emitter.On(EventClosing, func(args ...interface{}) { closed = append(closed, "closing") })
+	emitter.On(EventClosed, func(args ...interface{}) { closed = append(closed, "closed") })
+	emitter.Close()
+	emitter.Close()
+	expect(t, "closing,closed", strings.Join(closed, ","), "Close emits the closing events once")
+}
+
+func TestPauseHoldsAllEmits(t *testing.T) {
+	emitter := New()
+	delivered := int32(0)
+	emitter.On("job", func(args ...interface{}) { atomic.AddInt32(&delivered, 1) })
+	emitter.OnHandler("job", func(args ...interface{}) bool { atomic.AddInt32(&delivered, 1); return true })
+
+	emitter.Pause()
+	emitter.EmitBalanced("job")
+	expect(t, false, emitter.EmitFirst("job"), "held, not handled yet")
+	emitter.EmitMulti([]string{"job"})
+	emitter.EmitLocal("job")
+	expect(t, nil, emitter.EmitSyncTimeout("job", time.Second))
+	expect(t, nil, emitter.EmitReduce("job", nil))
+	emitter.EmitWith("job", nil)
+	emitter.EmitPattern("job")
+	result := emitter.EmitAsyncResult("job")
+	expect(t, int32(0), atomic.LoadInt32(&delivered), "all the emits are held")
+
+	waited := make(chan error)
+	go func() { waited <- emitter.EmitAsyncWait(context.Background(), "job") }()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expect(t, context.Canceled, emitter.EmitAsyncWait(ctx, "job"), "given up while held")
+
+	emitter.Resume()
+	result.Wait()
+	expect(t, nil, <-waited, "waited until resumed")
+	expect(t, int32(22), atomic.LoadInt32(&delivered))
+}
+
+func TestPoolSaturatedEvent(t *testing.T) {
+	pool := NewPool(1)
+	defer pool.Close()
+	emitter := New(WithPool(pool))
+
+	saturated := make(chan int, 10)
+	emitter.On(EventPoolSaturated, func(args ...interface{}) { saturated <- args[1].(int) })
+	release := make(chan bool)
+	emitter.On("job", func(args ...interface{}) { <-release })
+
+	emitter.EmitAsync("job", nil)
+	for pool.Busy() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	emitter.EmitAsync("job", nil)
+	emitter.EmitAsync("job", nil)
+	expect(t, 1, <-saturated)
+	expect(t, 0, len(saturated), "once until drained")
+	close(release)
+}
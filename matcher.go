@@ -0,0 +1,40 @@
+package Emitter
+
+// Matcher - a dialect matching the event names against the listeners' patterns
+type Matcher interface {
+	Match(pattern, event string) bool
+}
+
+// CompilingMatcher - a Matcher able to parse a pattern once, for matching it against many names
+type CompilingMatcher interface {
+	Matcher
+	Compile(pattern string) func(event string) bool
+}
+
+// MatcherFunc - a function used as a Matcher
+type MatcherFunc func(pattern, event string) bool
+
+// Match() - report whether the event name matches the pattern
+func (self MatcherFunc) Match(pattern, event string) bool {
+	return self(pattern, event)
+}
+
+// Glob - the default dialect, see Match: "*" matches any run of characters, separators included,
+// and "**" alone matches every event
+var Glob Matcher = MatcherFunc(Match)
+
+// WithMatcher() - match the patterns of the emitter's listeners, exceptions, reservations and
+// concurrency limits with the dialect instead of Glob
+func WithMatcher(matcher Matcher) Option {
+	return func(e *Emitter) {
+		e.matcher = matcher
+	}
+}
+
+// Matcher() - the dialect of the emitter's patterns
+func (self *Emitter) Matcher() Matcher {
+	if self.matcher == nil {
+		return Glob
+	}
+	return self.matcher
+}
@@ -0,0 +1,156 @@
+package Emitter
+
+import (
+	"math/rand"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// referenceMatch() - the reference translation of a pattern to a regexp, "*" being ".*"
+func referenceMatch(pattern, event string) bool {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^(?s:" + strings.Join(parts, ".*") + ")$").MatchString(event)
+}
+
+// name - a short random string of a small alphabet, so the patterns and the names overlap often
+type name string
+
+func (name) Generate(r *rand.Rand, size int) reflect.Value {
+	alphabet := []rune("ab.*é")
+	runes := make([]rune, r.Intn(8))
+	for i := range runes {
+		runes[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return reflect.ValueOf(name(runes))
+}
+
+func TestMatchProperties(t *testing.T) {
+	config := &quick.Config{MaxCount: 20000}
+
+	consistent := func(pattern, event name) bool {
+		return Match(string(pattern), string(event)) == referenceMatch(string(pattern), string(event))
+	}
+	if err := quick.Check(consistent, config); err != nil {
+		t.Error("Match isn't consistent with the regexp translation:", err)
+	}
+
+	reflexive := func(event name) bool {
+		return Match(string(event), string(event)) && Match("**", string(event)) && Match("*", string(event))
+	}
+	if err := quick.Check(reflexive, config); err != nil {
+		t.Error(err)
+	}
+
+	// a pattern made of the event's prefix and suffix around a star matches it
+	split := func(event name, at uint8) bool {
+		runes := []rune(string(event))
+		i := int(at) % (len(runes) + 1)
+		return Match(string(runes[:i])+"*"+string(runes[i:]), string(event))
+	}
+	if err := quick.Check(split, config); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestGlobMatcher(t *testing.T) {
+	expect(t, true, Glob.Match("user.*", "user.created"))
+	expect(t, false, Glob.Match("user.*", "account.created"))
+}
+
+// the recursive matcher took exponential time on these, they must now grow linearly with the name
+func BenchmarkMatchPathological(b *testing.B) {
+	pattern := strings.Repeat("*a", 16) + "b"
+	for _, n := range []int{16, 64, 256} {
+		event := strings.Repeat("a", n)
+		b.Run(strconv.Itoa(n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Match(pattern, event)
+			}
+		})
+	}
+}
+
+func BenchmarkMatch(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		Match("user.*.updated", "user.42.profile.updated")
+	}
+}
+
+func TestMatchPathologicalIsBounded(t *testing.T) {
+	done := make(chan bool)
+	go func() {
+		done <- Match(strings.Repeat("*a", 32)+"b", strings.Repeat("a", 4096))
+	}()
+	select {
+	case matched := <-done:
+		expect(t, false, matched)
+	case <-time.After(5 * time.Second):
+		t.Fatal("pathological pattern took too long")
+	}
+}
+
+func TestCompiledPatternProperties(t *testing.T) {
+	consistent := func(pattern, event name) bool {
+		return compilePattern(string(pattern)).match(string(event)) == Match(string(pattern), string(event))
+	}
+	if err := quick.Check(consistent, &quick.Config{MaxCount: 20000}); err != nil {
+		t.Error("the compiled pattern isn't consistent with Match:", err)
+	}
+}
+
+func BenchmarkListeners(b *testing.B) {
+	emitter := Construct()
+	for i := 0; i < 100; i++ {
+		emitter.On("service"+strconv.Itoa(i)+".*.updated", func(args ...interface{}) {})
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		emitter.Listeners("service42.user.updated")
+	}
+}
+
+func TestWithMatcher(t *testing.T) {
+	exact := MatcherFunc(func(pattern, event string) bool { return pattern == event })
+	emitter := New(WithMatcher(exact))
+	expect(t, true, emitter.Matcher() != nil)
+
+	calls := 0
+	emitter.On("user.*", func(args ...interface{}) { calls++ })
+	emitter.EmitSync("user.created")
+	expect(t, 0, calls, "the wildcard has no meaning in the dialect")
+	emitter.EmitSync("user.*")
+	expect(t, 1, calls)
+	expect(t, 1, len(emitter.Clone().Listeners("user.*")), "the clone keeps the dialect")
+}
+
+type prefixMatcher struct{ compiles int }
+
+func (self *prefixMatcher) Match(pattern, event string) bool {
+	return strings.HasPrefix(event, pattern)
+}
+
+func (self *prefixMatcher) Compile(pattern string) func(event string) bool {
+	self.compiles++
+	return func(event string) bool { return strings.HasPrefix(event, pattern) }
+}
+
+func TestWithCompilingMatcher(t *testing.T) {
+	matcher := &prefixMatcher{}
+	emitter := New(WithMatcher(matcher))
+
+	calls := 0
+	emitter.On("user.", func(args ...interface{}) { calls++ })
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("user.removed")
+	emitter.EmitSync("order.created")
+	expect(t, 2, calls)
+	expect(t, 1, matcher.compiles, "the pattern is compiled once")
+}
@@ -0,0 +1,56 @@
+package Emitter
+
+// ExcludeMetaFromWildcards() - stop delivering the meta-events ("newListener", "removeListener" ...)
+// to the wildcard listeners like "**", only the listeners of their exact name receive them then
+func (self *Emitter) ExcludeMetaFromWildcards(exclude bool) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.excludeMeta = exclude
+	self.invalidate()
+	return self
+}
+
+// ExcludeMeta() - stop delivering the meta-events to the subscribed listener if it's a wildcard one
+func (self *Subscription) ExcludeMeta() *Subscription {
+	return self.update(func(l *Listener) { l.excludeMeta = true })
+}
+
+// Except() - stop delivering the events matching any of the patterns to the subscribed listener,
+// i.e to filter the noisy events of a wildcard subscription out
+func (self *Subscription) Except(patterns ...string) *Subscription {
+	return self.update(func(l *Listener) {
+		for _, pattern := range patterns {
+			l.except = append(l.except, self.emitter.key(pattern))
+		}
+	})
+}
+
+// update() - change the subscribed listener under the lock
+func (self *Subscription) update(change func(l *Listener)) *Subscription {
+	e := self.emitter
+	keys := self.keys()
+
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+
+	for _, event := range keys {
+		for i, l := range e.listeners[event] {
+			if l.id == self.id {
+				change(&e.listeners[event][i])
+			}
+		}
+	}
+	e.invalidate()
+	return self
+}
+
+// excepts() - whether the listener excludes the event, the emitter's mutex must be held
+func (self Listener) excepts(e *Emitter, event string) bool {
+	for _, pattern := range self.except {
+		if e.compiled(pattern).match(event) {
+			return true
+		}
+	}
+	return false
+}
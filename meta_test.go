@@ -0,0 +1,39 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestExcludeMetaFromWildcards(t *testing.T) {
+	emitter := Construct()
+
+	all, some, meta := 0, 0, 0
+	emitter.On("**", func(args ...interface{}) { all++ })
+	emitter.OnEvent("*", func(ev *Event) { some++ }).ExcludeMeta()
+	emitter.On("newListener", func(args ...interface{}) { meta++ })
+	all, some, meta = 0, 0, 0
+
+	emitter.On("otherevent", func(args ...interface{}) {})
+	emitter.EmitSync("testevent")
+	expect(t, 2, all, "newListener and testevent")
+	expect(t, 1, some)
+	expect(t, 1, meta)
+
+	emitter.ExcludeMetaFromWildcards(true)
+	emitter.On("otherevent", func(args ...interface{}) {})
+	emitter.EmitSync("testevent")
+	expect(t, 3, all)
+	expect(t, 2, some)
+	expect(t, 2, meta)
+}
+
+func TestExcept(t *testing.T) {
+	emitter := Construct()
+
+	names := []string{}
+	emitter.OnEvent("user.*", func(ev *Event) { names = append(names, ev.Name) }).Except("user.heartbeat", "user.ping*")
+
+	emitter.EmitSync("user.created").EmitSync("user.heartbeat").EmitSync("user.pingpong").EmitSync("user.deleted")
+	expect(t, 2, len(names))
+	expect(t, "user.deleted", names[1])
+}
@@ -0,0 +1,337 @@
+// Package mqttbridge feeds local listeners from MQTT topics and publishes local events to them.
+//
+// Topic levels map to the dot separated parts of the event names, so the topic
+// "sensors/kitchen/temp" is the event "sensors.kitchen.temp", and the emitter's
+// wildcards are translated to the MQTT ones (a `*` spanning levels becomes `#`).
+// The bridge doesn't depend on an MQTT client, adapt yours to the Client interface, i.e for paho:
+//
+//	type pahoClient struct{ mqtt.Client }
+//
+//	func (c pahoClient) Publish(topic string, qos byte, payload []byte) error {
+//		t := c.Client.Publish(topic, qos, false, payload)
+//		t.Wait()
+//		return t.Error()
+//	}
+//
+//	func (c pahoClient) Subscribe(filter string, qos byte, handler func(string, []byte)) error {
+//		t := c.Client.Subscribe(filter, qos, func(_ mqtt.Client, m mqtt.Message) { handler(m.Topic(), m.Payload()) })
+//		t.Wait()
+//		return t.Error()
+//	}
+//
+//	func (c pahoClient) Unsubscribe(filters ...string) error {
+//		t := c.Client.Unsubscribe(filters...)
+//		t.Wait()
+//		return t.Error()
+//	}
+package mqttbridge
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+	"github.com/moleculer-go/goemitter/codec"
+	"github.com/moleculer-go/goemitter/internal/bridge"
+)
+
+// Client - the MQTT connection used by the bridge
+type Client interface {
+	Publish(topic string, qos byte, payload []byte) error
+	Subscribe(filter string, qos byte, handler func(topic string, payload []byte)) error
+	Unsubscribe(filters ...string) error
+}
+
+// Bridge - translates between the emitter's events and MQTT topics
+type Bridge struct {
+	// Codec - the payload serialization, ignored in Raw mode
+	Codec codec.Codec
+	// Raw - exchange plain payloads instead of envelopes: received payloads are emitted as a single
+	// []byte arg, and the first arg of local events is published as is ([]byte/string) or as json
+	Raw bool
+	// QoS - the quality of service of the publishes and subscriptions
+	QoS byte
+	// Prefix - the topic levels prepended to the event names, i.e "app/"
+	Prefix string
+	// OnError - called with the errors of publishing/decoding, if set
+	OnError func(err error)
+	// Relay - also publish the events received from other bridges, through MaxHops bridges at most
+	// and never back through a bridge they went through, see Emitter.Forwards; ignored in Raw mode,
+	// whose payloads can't carry the bridges an event went through
+	Relay   bool
+	MaxHops int
+
+	id       string
+	emitter  *Emitter.Emitter
+	client   Client
+	patterns []string
+	filters  []string
+	listener *Emitter.Subscription
+	echoes   map[string]int
+	mutex    *sync.Mutex
+}
+
+// New() - create a new bridge between the emitter and the MQTT client
+func New(e *Emitter.Emitter, client Client) *Bridge {
+	return &Bridge{
+		Codec:   codec.Typed(e, codec.JSON),
+		MaxHops: Emitter.DefaultMaxHops,
+		id:      bridge.NewID(),
+		emitter: e,
+		client:  client,
+		echoes:  make(map[string]int),
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Attach() - create a new bridge feeding and forwarding the events matching the specified patterns
+func Attach(e *Emitter.Emitter, client Client, patterns ...string) (*Bridge, error) {
+	bridge := New(e, client)
+	for _, pattern := range patterns {
+		// the topic filter is broader than the pattern, the received events are matched against it
+		if err := bridge.feed(TopicFilter(pattern), pattern); err != nil {
+			return bridge, err
+		}
+	}
+	bridge.Forward(patterns...)
+	return bridge, nil
+}
+
+// ID() - the origin id stamped on the events published by the bridge
+func (self *Bridge) ID() string {
+	return self.id
+}
+
+// Feed() - subscribe to the MQTT topic filters and emit their messages locally
+func (self *Bridge) Feed(filters ...string) error {
+	for _, filter := range filters {
+		if err := self.feed(filter, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// feed() - subscribe to the MQTT topic filter and emit its messages matching the pattern (all if "") locally
+func (self *Bridge) feed(filter, pattern string) error {
+	if err := self.client.Subscribe(self.Prefix+filter, self.QoS, self.receiver(self.Prefix+filter, pattern)); err != nil {
+		return err
+	}
+	self.mutex.Lock()
+	self.filters = append(self.filters, self.Prefix+filter)
+	self.mutex.Unlock()
+	return nil
+}
+
+// Forward() - publish the local events matching the patterns to their topics
+func (self *Bridge) Forward(patterns ...string) {
+	self.mutex.Lock()
+	self.patterns = append(self.patterns, patterns...)
+	listening := self.listener != nil
+	self.mutex.Unlock()
+
+	if !listening {
+		listener := self.emitter.OnEvent("**", self.publish).Bridge()
+		self.mutex.Lock()
+		self.listener = listener
+		self.mutex.Unlock()
+	}
+}
+
+// Close() - stop forwarding and unsubscribe the fed topic filters
+func (self *Bridge) Close() error {
+	self.mutex.Lock()
+	listener, filters := self.listener, self.filters
+	self.listener, self.filters, self.patterns = nil, nil, nil
+	self.mutex.Unlock()
+
+	if listener != nil {
+		listener.Remove()
+	}
+	if len(filters) == 0 {
+		return nil
+	}
+	return self.client.Unsubscribe(filters...)
+}
+
+// receiver() - emit the messages of the topic filter matching the pattern locally
+func (self *Bridge) receiver(filter, pattern string) func(string, []byte) {
+	return func(topic string, payload []byte) {
+		// clients may hand the messages of overlapping subscriptions to every handler
+		if MatchTopic(filter, topic) {
+			self.receive(topic, payload, pattern)
+		}
+	}
+}
+
+// receive() - emit a received message locally, unless it's the echo of our own publish,
+// it went through the bridge already or it doesn't match the pattern
+func (self *Bridge) receive(topic string, payload []byte, pattern string) {
+	key := topic + "\x00" + string(payload)
+	self.mutex.Lock()
+	if self.echoes[key] > 0 {
+		if self.echoes[key]--; self.echoes[key] == 0 {
+			delete(self.echoes, key)
+		}
+		self.mutex.Unlock()
+		return
+	}
+	self.mutex.Unlock()
+
+	ev := &Emitter.Event{Name: TopicEvent(strings.TrimPrefix(topic, self.Prefix)), Args: []interface{}{payload}}
+	if !self.Raw {
+		decoded, err := self.Codec.Unmarshal(payload)
+		if err != nil {
+			bridge.Report(self.OnError, err)
+			return
+		}
+		if decoded.Name == "" {
+			decoded.Name = ev.Name
+		}
+		ev = decoded
+	}
+	if ev.Visited(self.id) || (pattern != "" && !Emitter.Match(pattern, ev.Name)) {
+		return
+	}
+
+	// mark it remote so it isn't published back
+	if ev.Headers[Emitter.HeaderOrigin] == "" {
+		if ev.Headers == nil {
+			ev.Headers = map[string]string{}
+		}
+		ev.Headers[Emitter.HeaderOrigin] = topic
+	}
+	ev.Received(self.id)
+	self.emitter.EmitEvent(ev)
+}
+
+// publish() - publish the locally originated events matching the bridge's patterns
+func (self *Bridge) publish(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) || !self.relays(ev) || !self.forwards(ev.Name) {
+		return
+	}
+
+	payload, err := self.payload(ev)
+	if err != nil {
+		bridge.Report(self.OnError, err)
+		return
+	}
+
+	topic := self.Prefix + EventTopic(ev.Name)
+	key := topic + "\x00" + string(payload)
+
+	self.mutex.Lock()
+	subscribed := false
+	for _, filter := range self.filters {
+		subscribed = subscribed || MatchTopic(filter, topic)
+	}
+	if subscribed {
+		// the broker sends it back to us, skip it when it arrives
+		self.echoes[key]++
+	}
+	self.mutex.Unlock()
+
+	if err := self.client.Publish(topic, self.QoS, payload); err != nil {
+		bridge.Report(self.OnError, err)
+
+		if subscribed {
+			self.mutex.Lock()
+			if self.echoes[key]--; self.echoes[key] <= 0 {
+				delete(self.echoes, key)
+			}
+			self.mutex.Unlock()
+		}
+	}
+}
+
+// payload() - the MQTT payload of the event
+func (self *Bridge) payload(ev *Emitter.Event) ([]byte, error) {
+	if !self.Raw {
+		return self.Codec.Marshal(ev.Forwarded(self.id))
+	}
+	if len(ev.Args) == 0 {
+		return []byte{}, nil
+	}
+	switch arg := ev.Args[0].(type) {
+	case []byte:
+		return arg, nil
+	case string:
+		return []byte(arg), nil
+	default:
+		return json.Marshal(arg)
+	}
+}
+
+// relays() - whether the event is published: the locally originated ones, the received ones only
+// if relaying envelopes
+func (self *Bridge) relays(ev *Emitter.Event) bool {
+	if self.Raw {
+		return ev.Headers[Emitter.HeaderOrigin] == ""
+	}
+	return Emitter.Forwards(ev, self.id, self.Relay, self.MaxHops)
+}
+
+// forwards() - whether the event matches one of the forwarded patterns
+func (self *Bridge) forwards(event string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, pattern := range self.patterns {
+		if Emitter.Match(pattern, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// EventTopic() - the MQTT topic of the event name
+func EventTopic(event string) string {
+	return strings.Replace(event, ".", "/", -1)
+}
+
+// TopicEvent() - the event name of the MQTT topic
+func TopicEvent(topic string) string {
+	return strings.Replace(topic, "/", ".", -1)
+}
+
+// TopicFilter() - translate an emitter pattern to the (broadest) MQTT topic filter covering it,
+// as `*` may span several levels anything from the first wildcard on is covered by "#"
+func TopicFilter(pattern string) string {
+	levels := strings.Split(pattern, ".")
+	for i, level := range levels {
+		if strings.Contains(level, "*") {
+			return strings.Join(append(levels[:i], "#"), "/")
+		}
+	}
+	return strings.Join(levels, "/")
+}
+
+// EventPattern() - translate an MQTT topic filter to an emitter pattern, "+" and "#" both
+// become `*`, which is broader for "+" as it also spans levels
+func EventPattern(filter string) string {
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		if level == "+" || level == "#" {
+			levels[i] = "*"
+		}
+	}
+	if pattern := strings.Join(levels, "."); pattern != "*" {
+		return pattern
+	}
+	return "**"
+}
+
+// MatchTopic() - report whether the topic matches the MQTT topic filter
+func MatchTopic(filter, topic string) bool {
+	filters, levels := strings.Split(filter, "/"), strings.Split(topic, "/")
+	for i, f := range filters {
+		if f == "#" {
+			return true
+		}
+		if i >= len(levels) || (f != "+" && f != levels[i]) {
+			return false
+		}
+	}
+	return len(filters) == len(levels)
+}
@@ -0,0 +1,163 @@
+package mqttbridge
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// fakeBroker - an in memory MQTT broker delivering synchronously, echoing back to the publisher
+type fakeBroker struct {
+	subs  map[string]func(string, []byte)
+	mutex sync.Mutex
+}
+
+func (self *fakeBroker) Publish(topic string, qos byte, payload []byte) error {
+	self.mutex.Lock()
+	handlers := []func(string, []byte){}
+	for filter, handler := range self.subs {
+		if MatchTopic(filter, topic) {
+			handlers = append(handlers, handler)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, handler := range handlers {
+		handler(topic, payload)
+	}
+	return nil
+}
+
+func (self *fakeBroker) Subscribe(filter string, qos byte, handler func(string, []byte)) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.subs[filter] = handler
+	return nil
+}
+
+func (self *fakeBroker) Unsubscribe(filters ...string) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	for _, filter := range filters {
+		delete(self.subs, filter)
+	}
+	return nil
+}
+
+func TestRawFeed(t *testing.T) {
+	broker := &fakeBroker{subs: map[string]func(string, []byte){}}
+	emitter := Emitter.Construct()
+
+	bridge := New(emitter, broker)
+	bridge.Raw = true
+	bridge.Feed("sensors/+/temp")
+	bridge.Forward("sensors.*")
+
+	received := []string{}
+	emitter.On("sensors.*", func(args ...interface{}) {
+		received = append(received, fmt.Sprintf("%s", args[0]))
+	})
+
+	// a device publishing, then a local publish whose echo must be skipped
+	broker.Publish("sensors/kitchen/temp", 0, []byte("21.5"))
+	emitter.EmitSync("sensors.garage.temp", "18")
+
+	expect(t, 2, len(received))
+	expect(t, "21.5", received[0])
+	expect(t, 0, len(bridge.echoes))
+
+	bridge.Close()
+	expect(t, 0, len(broker.subs))
+	expect(t, 1, emitter.ListenersCount("sensors.kitchen.temp"))
+}
+
+func TestEnvelopes(t *testing.T) {
+	broker := &fakeBroker{subs: map[string]func(string, []byte){}}
+	e1, e2 := Emitter.Construct(), Emitter.Construct()
+	Attach(e1, broker, "user.*")
+	Attach(e2, broker, "user.*")
+
+	count1, count2 := 0, 0
+	e1.On("user.created", func(args ...interface{}) { count1++ })
+	e2.On("user.created", func(args ...interface{}) {
+		count2++
+		expect(t, "john", args[0])
+	})
+
+	e1.EmitSync("user.created", "john")
+
+	expect(t, 1, count1)
+	expect(t, 1, count2)
+}
+
+func TestPatternFilter(t *testing.T) {
+	broker := &fakeBroker{subs: map[string]func(string, []byte){}}
+	emitter := Emitter.Construct()
+	Attach(emitter, broker, "user.*.done")
+
+	received := []string{}
+	emitter.OnEvent("user.**", func(ev *Emitter.Event) {
+		received = append(received, ev.Name)
+	})
+
+	// both are delivered for "user/#", only the first matches the pattern
+	broker.Publish("user/john/done", 0, []byte(`{"args":["john"]}`))
+	broker.Publish("user/john/created", 0, []byte(`{"args":["john"]}`))
+
+	expect(t, 1, len(received))
+	expect(t, "user.john.done", received[0])
+}
+
+func TestRelay(t *testing.T) {
+	a := &fakeBroker{subs: map[string]func(string, []byte){}}
+	b := &fakeBroker{subs: map[string]func(string, []byte){}}
+	e1, e2, e3 := Emitter.Construct(), Emitter.Construct(), Emitter.Construct()
+
+	Attach(e1, a, "user.*")
+	relayA, relayB := New(e2, a), New(e2, b)
+	relayA.Relay, relayB.Relay = true, true
+	relayA.Feed("user/#")
+	relayA.Forward("user.*")
+	relayB.Feed("user/#")
+	relayB.Forward("user.*")
+	Attach(e3, b, "user.*")
+
+	count1, count3 := 0, 0
+	e1.On("user.created", func(args ...interface{}) { count1++ })
+	e3.OnEvent("user.created", func(ev *Emitter.Event) {
+		count3++
+		expect(t, 4, ev.Hops())
+	})
+
+	// through e2 to e3, forwarded and received by a bridge on each hop, and not back to e1
+	e1.EmitSync("user.created", "john")
+	expect(t, 1, count1)
+	expect(t, 1, count3)
+
+	// not relaying, the events received by e2 stay there
+	relayB.Relay = false
+	e1.EmitSync("user.created", "jane")
+	expect(t, 2, count1)
+	expect(t, 1, count3)
+}
+
+func TestTranslation(t *testing.T) {
+	expect(t, "#", TopicFilter("**"))
+	expect(t, "user/#", TopicFilter("user.*"))
+	expect(t, "user/created", TopicFilter("user.created"))
+	expect(t, "**", EventPattern("#"))
+	expect(t, "sensors.*.temp", EventPattern("sensors/+/temp"))
+
+	expect(t, true, MatchTopic("sensors/+/temp", "sensors/kitchen/temp"))
+	expect(t, false, MatchTopic("sensors/+/temp", "sensors/kitchen/fridge/temp"))
+	expect(t, true, MatchTopic("sensors/#", "sensors"))
+	expect(t, false, MatchTopic("sensors/kitchen", "sensors"))
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
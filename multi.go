@@ -0,0 +1,157 @@
+package Emitter
+
+// Subscriptions - the handles of the listeners registered at once by OnEvents
+type Subscriptions []*Subscription
+
+// OnEvents() - register the callback on each of the events, atomically
+func (self *Emitter) OnEvents(events []string, callback func(...interface{})) Subscriptions {
+	allowed := make([]string, 0, len(events))
+	for _, event := range events {
+		if !self.reserved(event, false) {
+			allowed = append(allowed, event)
+		}
+	}
+
+	if len(allowed) == 0 || !self.lockUnfrozen(allowed...) {
+		return Subscriptions{}
+	}
+	subscriptions := make(Subscriptions, 0, len(allowed))
+	added := make([]Listener, 0, len(allowed))
+	for _, event := range allowed {
+		event = self.key(event)
+		self.sequence++
+		listener := Listener{callback: callback, event: event, id: self.sequence}
+		self.track(&listener)
+		self.compiled(event)
+		self.listeners[event] = append(self.listeners[event], listener)
+		subscriptions = append(subscriptions, &Subscription{emitter: self, event: event, id: listener.id, stats: listener.stats})
+		added = append(added, listener)
+	}
+	self.mutex.Unlock()
+
+	for _, l := range added {
+		self.validateListener(l.event)
+		self.deprecation(l.event)
+		self.listenerAdded(l.event, l)
+	}
+	return subscriptions
+}
+
+// OnPatterns() - register the callback on several patterns at once, evaluated as an OR: it runs once
+// per emit even if several patterns match, the returned subscription controls all of them
+func (self *Emitter) OnPatterns(patterns []string, callback func(...interface{})) *Subscription {
+	allowed := make([]string, 0, len(patterns))
+	for _, pattern := range patterns {
+		if !self.reserved(pattern, false) {
+			allowed = append(allowed, pattern)
+		}
+	}
+
+	if len(allowed) == 0 || !self.lockUnfrozen(allowed...) {
+		return &Subscription{emitter: self, patterns: []string{}}
+	}
+	self.sequence++
+	listener := Listener{callback: callback, id: self.sequence}
+	self.track(&listener)
+	subscription := &Subscription{emitter: self, id: listener.id, patterns: []string{}, stats: listener.stats}
+	for _, pattern := range allowed {
+		listener.event = self.key(pattern)
+		self.compiled(listener.event)
+		self.listeners[listener.event] = append(self.listeners[listener.event], listener)
+		subscription.patterns = append(subscription.patterns, listener.event)
+	}
+	self.mutex.Unlock()
+
+	for _, pattern := range subscription.patterns {
+		listener.event = pattern
+		self.validateListener(pattern)
+		self.deprecation(pattern)
+		self.listenerAdded(pattern, listener)
+	}
+	return subscription
+}
+
+// RemoveListeners() - remove the callback from each of the events' listeners, atomically
+func (self *Emitter) RemoveListeners(events []string, callback func(...interface{})) *Emitter {
+	ptr := Listener{callback: callback}.pointer()
+	return self.removeEventsInternal(events, func(l Listener) bool {
+		return l.handler == nil && l.pointer() == ptr
+	})
+}
+
+// Remove() - remove all the subscribed listeners, atomically
+func (self Subscriptions) Remove() {
+	if len(self) == 0 {
+		return
+	}
+
+	emitter := self[0].emitter
+	events := make([]string, len(self))
+	ids := make(map[uint64]bool, len(self))
+	for i, s := range self {
+		events[i] = emitter.eventOf(s.event, s.id)
+		ids[s.id] = true
+	}
+	emitter.removeEventsInternal(events, func(l Listener) bool {
+		return ids[l.id]
+	})
+}
+
+// removeEventsInternal() - remove the first listener matching on each of the events under a single lock
+func (self *Emitter) removeEventsInternal(events []string, match func(Listener) bool) *Emitter {
+	if !self.lockUnfrozen(events...) {
+		return self
+	}
+	removed := []Listener{}
+	for _, event := range events {
+		event = self.key(event)
+		for k, v := range self.listeners[event] {
+			if match(v) {
+				self.listeners[event] = append(self.listeners[event][:k], self.listeners[event][k+1:]...)
+				removed = append(removed, v)
+				break
+			}
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, v := range removed {
+		self.listenerRemoved(v.event, v)
+	}
+	return self
+}
+
+// EmitMulti() - run the listeners of several events as one operation, in synchronous mode:
+// the listeners of all the events are captured at once so registrations made by the listeners
+// don't leak into the later events, and a one-time listener matching several events runs once
+func (self *Emitter) EmitMulti(events []string, args ...interface{}) *Emitter {
+	self.mutex.Lock()
+	snapshot := make([][]Listener, len(events))
+	for i, event := range events {
+		snapshot[i] = self.matchListeners(event)
+	}
+	self.mutex.Unlock()
+
+	ran := map[uint64]bool{}
+	for i, event := range events {
+		listeners := snapshot[i]
+		self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+			if !self.admit(ev) {
+				return -1
+			}
+			// the one-time listeners already run by a previous event are skipped, run() claims the others
+			pending := make([]Listener, 0, len(listeners))
+			for _, v := range listeners {
+				if v.once {
+					if ran[v.id] {
+						continue
+					}
+					ran[v.id] = true
+				}
+				pending = append(pending, v)
+			}
+			return self.run(ev, pending, async)
+		})
+	}
+	return self
+}
@@ -0,0 +1,81 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestOnEvents(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	callback := func(args ...interface{}) { counter++ }
+
+	subscriptions := emitter.OnEvents([]string{"a", "b", "c"}, callback)
+	emitter.EmitSync("a").EmitSync("b").EmitSync("c")
+	expect(t, 3, counter)
+
+	emitter.RemoveListeners([]string{"a", "b"}, callback)
+	emitter.EmitSync("a").EmitSync("b").EmitSync("c")
+	expect(t, 4, counter)
+
+	subscriptions.Remove()
+	emitter.EmitSync("c")
+	expect(t, 4, counter)
+	expect(t, 0, emitter.ListenersCount("c"))
+}
+
+func TestEmitMulti(t *testing.T) {
+	emitter := Construct()
+
+	names := []string{}
+	emitter.OnEvent("*.*", func(ev *Event) {
+		names = append(names, ev.Name)
+		if len(names) == 1 {
+			// not part of the snapshot
+			emitter.On("audit.record", func(args ...interface{}) { t.Error("registered during the emit") })
+		}
+	})
+	once := 0
+	emitter.Once("*.*", func(args ...interface{}) { once++ })
+
+	emitter.EmitMulti([]string{"user.created", "audit.record"}, "john")
+	expect(t, 2, len(names))
+	expect(t, "audit.record", names[1])
+	expect(t, 1, once)
+}
+
+func TestOnPatterns(t *testing.T) {
+	emitter := Construct()
+
+	counter := 0
+	subscription := emitter.OnPatterns([]string{"user.*", "*.created", "account.*"}, func(args ...interface{}) { counter++ })
+
+	emitter.EmitSync("user.created").EmitSync("account.deleted").EmitSync("order.shipped")
+	expect(t, 2, counter, "once per emit")
+
+	subscription.Except("account.*")
+	emitter.EmitSync("account.deleted")
+	expect(t, 2, counter)
+
+	subscription.Remove()
+	emitter.EmitSync("user.created")
+	expect(t, 2, counter)
+	expect(t, 0, emitter.ListenersCount("user.created"))
+}
+
+func TestEmitMultiExecutors(t *testing.T) {
+	emitter := New()
+	queued := []func(){}
+	executor := ExecutorFunc(func(task func()) { queued = append(queued, task) })
+	calls := 0
+	emitter.OnWith("user.*", func(args ...interface{}) { calls++ }, RunOn(executor))
+	emitter.OnWith("audit.*", func(args ...interface{}) { calls++ }, RunOn(executor))
+
+	emitter.EmitMulti([]string{"user.created", "audit.record"})
+	expect(t, 0, calls, "the invocations run on the listeners' executor")
+	expect(t, 2, len(queued))
+	for _, task := range queued {
+		task()
+	}
+	expect(t, 2, calls)
+}
@@ -0,0 +1,88 @@
+package Emitter
+
+import (
+	"sync/atomic"
+)
+
+// mute - the suppressed emits of the events matching the pattern
+type mute struct {
+	pattern    string
+	counted    bool
+	suppressed uint64
+}
+
+// Mute() - suppress the delivery of the emits of the events matching the pattern until Unmute, i.e to
+// silence a noisy subsystem during an incident; the meta-events can't be muted
+func (self *Emitter) Mute(pattern string) *Emitter {
+	return self.setMute(pattern, false)
+}
+
+// MuteCounted() - suppress the delivery of the emits of the events matching the pattern, as Mute does,
+// still counting them in the emits report, see TrackReport
+func (self *Emitter) MuteCounted(pattern string) *Emitter {
+	return self.setMute(pattern, true)
+}
+
+// Unmute() - deliver the emits of the events matching the pattern again
+func (self *Emitter) Unmute(pattern string) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.mutes = self.unmuted(self.key(pattern))
+	return self
+}
+
+// Muted() - the muted patterns with the count of the emits they suppressed
+func (self *Emitter) Muted() map[string]uint64 {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	muted := make(map[string]uint64, len(self.mutes))
+	for _, m := range self.mutes {
+		muted[m.pattern] = atomic.LoadUint64(&m.suppressed)
+	}
+	return muted
+}
+
+func (self *Emitter) setMute(pattern string, counted bool) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	self.mutes = append(self.unmuted(pattern), &mute{pattern: pattern, counted: counted})
+	return self
+}
+
+// unmuted() - the mutes but the pattern's one, the mutex must be held
+func (self *Emitter) unmuted(pattern string) []*mute {
+	mutes := self.mutes[:0:0]
+	for _, m := range self.mutes {
+		if m.pattern != pattern {
+			mutes = append(mutes, m)
+		}
+	}
+	return mutes
+}
+
+// muted() - whether the emit is suppressed by the mutes of its event, and whether it's still counted
+func (self *Emitter) muted(event string) (bool, bool) {
+	if IsMetaEvent(event) {
+		return false, false
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if len(self.mutes) == 0 {
+		return false, false
+	}
+	event = self.key(event)
+	muted, counted := false, false
+	for _, m := range self.mutes {
+		if self.compiled(m.pattern).match(event) {
+			atomic.AddUint64(&m.suppressed, 1)
+			muted, counted = true, counted || m.counted
+		}
+	}
+	return muted, counted
+}
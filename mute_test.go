@@ -0,0 +1,34 @@
+package Emitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMute(t *testing.T) {
+	emitter := New()
+	emitter.TrackReport(time.Minute, 5)
+	delivered, meta := 0, 0
+	emitter.On("cache.*", func(args ...interface{}) { delivered++ })
+	emitter.On("user.created", func(args ...interface{}) { delivered++ })
+	emitter.On("newListener", func(args ...interface{}) { meta++ })
+
+	emitter.Mute("cache.*").MuteCounted("user.*").Mute("newListener")
+	emitter.EmitSync("cache.miss")
+	emitter.EmitSync("cache.hit")
+	emitter.EmitSync("user.created")
+	emitter.On("order.created", func(args ...interface{}) {})
+	expect(t, 0, delivered, "the muted emits aren't delivered")
+	expect(t, 2, meta, "the meta-events can't be muted")
+	expect(t, uint64(2), emitter.Muted()["cache.*"])
+	expect(t, uint64(1), emitter.Muted()["user.*"])
+
+	report := emitter.Report()
+	expect(t, 1, len(report.Emits), "only the counted mutes are reported")
+	expect(t, EventCount{"user.created", 1}, report.Emits[0])
+
+	emitter.Unmute("cache.*")
+	emitter.EmitSync("cache.miss")
+	expect(t, 1, delivered)
+	expect(t, 2, len(emitter.Muted()))
+}
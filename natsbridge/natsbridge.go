@@ -0,0 +1,199 @@
+// Package natsbridge mirrors the events of an emitter to/from NATS subjects,
+// so several processes can share one event bus.
+//
+// Event names are mapped to subjects by prepending the bridge's prefix, so
+// "user.created" is published on "events.user.created" by default. The bridge
+// doesn't depend on a NATS client, adapt yours to the Conn interface:
+//
+//	type natsConn struct{ *nats.Conn }
+//
+//	func (c natsConn) Subscribe(subject string, handler func(string, []byte)) (natsbridge.Subscription, error) {
+//		return c.Conn.Subscribe(subject, func(m *nats.Msg) { handler(m.Subject, m.Data) })
+//	}
+package natsbridge
+
+import (
+	"strings"
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+	"github.com/moleculer-go/goemitter/codec"
+	"github.com/moleculer-go/goemitter/internal/bridge"
+)
+
+// Conn - the NATS connection used by the bridge
+type Conn interface {
+	Publish(subject string, data []byte) error
+	Subscribe(subject string, handler func(subject string, data []byte)) (Subscription, error)
+}
+
+// Subscription - a subscription of the NATS connection
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// Bridge - mirrors the events matching its patterns between an emitter and NATS
+type Bridge struct {
+	// Codec - the payload serialization, codec.JSON typed by the emitter's schemas by default
+	Codec codec.Codec
+	// Prefix - prepended to the event names to build the subjects
+	Prefix string
+	// OnError - called with the errors of publishing/decoding, if set
+	OnError func(err error)
+	// Relay - also publish the events received from other bridges, through MaxHops bridges at most
+	// and never back through a bridge they went through, see Emitter.Forwards
+	Relay   bool
+	MaxHops int
+
+	id            string
+	emitter       *Emitter.Emitter
+	conn          Conn
+	patterns      []string
+	subscriptions []Subscription
+	listener      *Emitter.Subscription
+	mutex         *sync.Mutex
+}
+
+// New() - create a new bridge between the emitter and the NATS connection
+func New(e *Emitter.Emitter, conn Conn) *Bridge {
+	return &Bridge{
+		Codec:   codec.Typed(e, codec.JSON),
+		Prefix:  "events.",
+		MaxHops: Emitter.DefaultMaxHops,
+		id:      bridge.NewID(),
+		emitter: e,
+		conn:    conn,
+		mutex:   &sync.Mutex{},
+	}
+}
+
+// Attach() - create a new bridge mirroring the events matching the specified patterns
+func Attach(e *Emitter.Emitter, conn Conn, patterns ...string) (*Bridge, error) {
+	bridge := New(e, conn)
+	return bridge, bridge.Mirror(patterns...)
+}
+
+// ID() - the origin id stamped on the events published by the bridge
+func (self *Bridge) ID() string {
+	return self.id
+}
+
+// Mirror() - publish the local events matching the patterns and emit the remote ones locally
+func (self *Bridge) Mirror(patterns ...string) error {
+	self.mutex.Lock()
+	var err error
+	for _, pattern := range patterns {
+		var sub Subscription
+		if sub, err = self.conn.Subscribe(SubjectPattern(self.Prefix, pattern), self.receiver(pattern)); err != nil {
+			break
+		}
+		self.subscriptions = append(self.subscriptions, sub)
+		self.patterns = append(self.patterns, pattern)
+	}
+	listening := self.listener != nil || len(self.patterns) == 0
+	self.mutex.Unlock()
+
+	// registering emits "newListener" which reaches publish, so not under the lock
+	if !listening {
+		listener := self.emitter.OnEvent("**", self.publish).Bridge()
+
+		self.mutex.Lock()
+		self.listener = listener
+		self.mutex.Unlock()
+	}
+	return err
+}
+
+// Close() - unsubscribe from NATS and stop listening on the emitter
+func (self *Bridge) Close() error {
+	self.mutex.Lock()
+	listener := self.listener
+	self.listener = nil
+	self.mutex.Unlock()
+
+	if listener != nil {
+		listener.Remove()
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var err error
+	for _, sub := range self.subscriptions {
+		if e := sub.Unsubscribe(); e != nil && err == nil {
+			err = e
+		}
+	}
+	self.subscriptions = nil
+	self.patterns = nil
+	return err
+}
+
+// publish() - publish the locally originated events matching the bridge's patterns
+func (self *Bridge) publish(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) || !Emitter.Forwards(ev, self.id, self.Relay, self.MaxHops) || !self.mirrors(ev.Name) {
+		return
+	}
+
+	data, err := self.Codec.Marshal(ev.Forwarded(self.id))
+	if err == nil {
+		err = self.conn.Publish(self.Prefix+ev.Name, data)
+	}
+	bridge.Report(self.OnError, err)
+}
+
+// receiver() - emit the remote events matching the pattern locally
+func (self *Bridge) receiver(pattern string) func(string, []byte) {
+	return func(subject string, data []byte) {
+		ev, err := self.Codec.Unmarshal(data)
+		if err != nil {
+			bridge.Report(self.OnError, err)
+			return
+		}
+		if ev.Name == "" {
+			ev.Name = strings.TrimPrefix(subject, self.Prefix)
+		}
+		if ev.Visited(self.id) || !Emitter.Match(pattern, ev.Name) {
+			return
+		}
+		if ev.Headers == nil || ev.Headers[Emitter.HeaderOrigin] == "" {
+			// published by a foreign producer, mark it remote so it isn't mirrored back
+			if ev.Headers == nil {
+				ev.Headers = map[string]string{}
+			}
+			ev.Headers[Emitter.HeaderOrigin] = subject
+		}
+		ev.Received(self.id)
+		self.emitter.EmitEvent(ev)
+	}
+}
+
+// mirrors() - whether the event matches one of the bridge's patterns
+func (self *Bridge) mirrors(event string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, pattern := range self.patterns {
+		if Emitter.Match(pattern, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// SubjectPattern() - translate an emitter pattern to the (broadest) NATS subject covering it,
+// the received events are matched against the original pattern anyway
+func SubjectPattern(prefix, pattern string) string {
+	if pattern == "**" {
+		return prefix + ">"
+	}
+
+	// the emitter's wildcards may span several tokens, so anything from the first one on is covered by ">"
+	tokens := strings.Split(pattern, ".")
+	for i, token := range tokens {
+		if strings.Contains(token, "*") {
+			return prefix + strings.Join(append(tokens[:i], ">"), ".")
+		}
+	}
+	return prefix + pattern
+}
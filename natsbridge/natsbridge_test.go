@@ -0,0 +1,124 @@
+package natsbridge
+
+import (
+	"strings"
+	"sync"
+	"testing"
+
+	Emitter "github.com/moleculer-go/goemitter"
+	"github.com/moleculer-go/goemitter/codec"
+)
+
+// fakeNATS - an in memory broker delivering the messages synchronously
+type fakeNATS struct {
+	subs  map[*fakeSub]bool
+	mutex sync.Mutex
+}
+
+type fakeSub struct {
+	nats    *fakeNATS
+	subject string
+	handler func(string, []byte)
+}
+
+func (self *fakeSub) Unsubscribe() error {
+	self.nats.mutex.Lock()
+	delete(self.nats.subs, self)
+	self.nats.mutex.Unlock()
+	return nil
+}
+
+func (self *fakeNATS) Publish(subject string, data []byte) error {
+	self.mutex.Lock()
+	subs := []*fakeSub{}
+	for sub := range self.subs {
+		prefix := strings.TrimSuffix(sub.subject, ">")
+		if sub.subject == subject || (prefix != sub.subject && strings.HasPrefix(subject, prefix)) {
+			subs = append(subs, sub)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, sub := range subs {
+		sub.handler(subject, data)
+	}
+	return nil
+}
+
+func (self *fakeNATS) Subscribe(subject string, handler func(string, []byte)) (Subscription, error) {
+	sub := &fakeSub{self, subject, handler}
+	self.mutex.Lock()
+	self.subs[sub] = true
+	self.mutex.Unlock()
+	return sub, nil
+}
+
+func TestMirror(t *testing.T) {
+	broker := &fakeNATS{subs: map[*fakeSub]bool{}}
+	e1, e2 := Emitter.Construct(), Emitter.Construct()
+
+	b1, _ := Attach(e1, broker, "user.*")
+	b2 := New(e2, broker)
+	b2.Codec = codec.Msgpack
+	b1.Codec = codec.Msgpack
+	b2.Mirror("user.*")
+
+	count1, count2 := 0, 0
+	e1.On("user.created", func(args ...interface{}) { count1++ })
+	e2.On("user.created", func(args ...interface{}) {
+		count2++
+		expect(t, "john", args[0])
+	})
+
+	e1.EmitSync("user.created", "john")
+	e1.EmitSync("account.created", "john")
+
+	expect(t, 1, count1)
+	expect(t, 1, count2)
+
+	b2.Close()
+	e1.EmitSync("user.created", "john")
+	expect(t, 1, count2)
+	expect(t, 1, e2.ListenersCount("user.created"))
+}
+
+func TestRelay(t *testing.T) {
+	x, y := &fakeNATS{subs: map[*fakeSub]bool{}}, &fakeNATS{subs: map[*fakeSub]bool{}}
+	e1, e2, e3 := Emitter.Construct(), Emitter.Construct(), Emitter.Construct()
+
+	// e2 and e3 are on both brokers, relaying: a loop
+	Attach(e1, x, "**")
+	for _, e := range []*Emitter.Emitter{e2, e3} {
+		for _, broker := range []*fakeNATS{x, y} {
+			b := New(e, broker)
+			b.Relay = true
+			b.Mirror("**")
+		}
+	}
+
+	counts := map[*Emitter.Emitter]int{}
+	for _, e := range []*Emitter.Emitter{e1, e2, e3} {
+		e := e
+		e.On("user.created", func(args ...interface{}) { counts[e]++ })
+	}
+
+	e1.EmitSync("user.created", "john")
+	// the loop ends, e2 and e3 receive the event directly and relayed by each other
+	expect(t, 1, counts[e1])
+	expect(t, 2, counts[e2])
+	expect(t, 2, counts[e3])
+}
+
+func TestSubjectPattern(t *testing.T) {
+	expect(t, "events.>", SubjectPattern("events.", "**"))
+	expect(t, "events.user.>", SubjectPattern("events.", "user.*"))
+	expect(t, "events.user.>", SubjectPattern("events.", "user.*.created"))
+	expect(t, "events.>", SubjectPattern("events.", "us*"))
+	expect(t, "events.user.created", SubjectPattern("events.", "user.created"))
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
@@ -0,0 +1,53 @@
+package Emitter
+
+// Off() - remove listeners with one entry point for the three removal styles: the target is a
+// subscription handle (*Subscription or SubscriptionID), or an event name or pattern whose listeners
+// registered on the names it matches are removed, i.e "user.*" covers the ones on "user.created" and
+// on "user.*" itself. With a name the callbacks are removed as RemoveListener does, without any
+// callback all the listeners are, as OffAll does
+func (self *Emitter) Off(target interface{}, callbacks ...func(...interface{})) *Emitter {
+	event, ok := target.(string)
+	if !ok || len(callbacks) == 0 {
+		return self.OffAll(target)
+	}
+	for _, key := range self.keysMatching(event) {
+		for _, callback := range callbacks {
+			self.RemoveListener(key, callback)
+		}
+	}
+	return self
+}
+
+// OffAll() - remove all the listeners of the target: a subscription handle, an event name or a pattern
+// (see Off), or nil for all the listeners of all the events
+func (self *Emitter) OffAll(target interface{}) *Emitter {
+	switch target := target.(type) {
+	case *Subscription:
+		target.Remove()
+	case SubscriptionID:
+		self.RemoveSubscription(target)
+	case string:
+		for _, key := range self.keysMatching(target) {
+			self.RemoveAllListeners(key)
+		}
+	case nil:
+		self.RemoveAllListeners(nil)
+	}
+	return self
+}
+
+// keysMatching() - the events (patterns) with listeners whose name matches the pattern
+func (self *Emitter) keysMatching(pattern string) []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	compiled := self.compiled(pattern)
+	keys := []string{}
+	for key := range self.listeners {
+		if key == pattern || compiled.match(key.(string)) {
+			keys = append(keys, key.(string))
+		}
+	}
+	return keys
+}
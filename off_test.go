@@ -0,0 +1,45 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestOff(t *testing.T) {
+	emitter := New()
+	fn := func(args ...interface{}) {}
+	other := func(args ...interface{}) {}
+
+	emitter.On("user.created", fn).On("user.removed", fn).On("user.*", fn).On("user.created", other).On("order.created", fn)
+	emitter.Off("user.*", fn)
+	expect(t, 1, emitter.ListenersCount("user.created"), "the other callback stays")
+	expect(t, 0, emitter.ListenersCount("user.removed"))
+	expect(t, 1, emitter.ListenersCount("order.created"))
+
+	emitter.Off("order.created", fn)
+	expect(t, 0, emitter.ListenersCount("order.created"))
+
+	sub := emitter.Subscribe("order.paid", fn)
+	id := emitter.Subscribe("order.paid", fn).ID()
+	emitter.Off(sub)
+	expect(t, 1, emitter.ListenersCount("order.paid"))
+	emitter.Off(id)
+	expect(t, 0, emitter.ListenersCount("order.paid"))
+
+	emitter.On("user.removed", fn)
+	emitter.Off("user.*")
+	expect(t, 0, emitter.ListenersCount("user.created"), "no callback removes them all")
+	expect(t, 0, emitter.ListenersCount("user.removed"))
+}
+
+func TestOffAll(t *testing.T) {
+	emitter := New()
+	fn := func(args ...interface{}) {}
+
+	emitter.On("a.one", fn).On("a.two", fn).On("b.one", fn)
+	emitter.OffAll("a.*")
+	expect(t, 0, emitter.ListenersCount("a.one"))
+	expect(t, 1, emitter.ListenersCount("b.one"))
+
+	emitter.OffAll(nil)
+	expect(t, 0, emitter.ListenersCount("b.one"))
+}
@@ -0,0 +1,60 @@
+package Emitter
+
+// Overlaps() - report whether some event name matches both (wildcard) patterns, i.e "user.*" and
+// "*.created" do with "user.created"
+func Overlaps(a, b string) bool {
+	if a == "**" || b == "**" || a == b {
+		return true
+	}
+	p, q := []rune(a), []rune(b)
+
+	// overlaps[i][j] - whether the suffixes p[i:] and q[j:] overlap, filled backwards
+	overlaps := make([][]bool, len(p)+1)
+	for i := range overlaps {
+		overlaps[i] = make([]bool, len(q)+1)
+	}
+	for i := len(p); i >= 0; i-- {
+		for j := len(q); j >= 0; j-- {
+			switch {
+			case i == len(p) && j == len(q):
+				overlaps[i][j] = true
+			case i < len(p) && p[i] == '*':
+				// the star matches nothing, or the next character of the other pattern too
+				overlaps[i][j] = overlaps[i+1][j] || (j < len(q) && overlaps[i][j+1])
+			case j < len(q) && q[j] == '*':
+				overlaps[i][j] = overlaps[i][j+1] || (i < len(p) && overlaps[i+1][j])
+			case i < len(p) && j < len(q):
+				overlaps[i][j] = p[i] == q[j] && overlaps[i+1][j+1]
+			}
+		}
+	}
+	return overlaps[0][0]
+}
+
+// CountMatching() - how many subscriptions would receive some event matching the pattern, i.e for
+// a preflight "is anyone listening to user.* at all?": the ones on the names it matches and on the
+// patterns overlapping it. With another dialect than Glob the patterns are compared one way and the other
+func (self *Emitter) CountMatching(pattern string) int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	seen := map[uint64]bool{}
+	for key, lis := range self.listeners {
+		if len(lis) == 0 || !self.overlaps(pattern, key.(string)) {
+			continue
+		}
+		for _, l := range lis {
+			seen[l.id] = true
+		}
+	}
+	return len(seen)
+}
+
+// overlaps() - whether some event matches both patterns in the emitter's dialect, the mutex must be held
+func (self *Emitter) overlaps(a, b string) bool {
+	if self.matcher == nil {
+		return Overlaps(a, b)
+	}
+	return self.compiled(a).match(b) || self.compiled(b).match(a)
+}
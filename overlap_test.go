@@ -0,0 +1,58 @@
+package Emitter
+
+import (
+	"testing"
+	"testing/quick"
+)
+
+func TestOverlaps(t *testing.T) {
+	cases := []struct {
+		a, b     string
+		overlaps bool
+	}{
+		{"user.*", "user.created", true},
+		{"user.*", "*.created", true},
+		{"user.*", "order.*", false},
+		{"user.*.updated", "*.profile.*", true},
+		{"a*b", "*c", false},
+		{"a*", "*a", true},
+		{"**", "anything", true},
+		{"user.created", "user.created", true},
+		{"user.created", "user.removed", false},
+		{"*", "", true},
+		{"a", "", false},
+	}
+	for _, c := range cases {
+		if Overlaps(c.a, c.b) != c.overlaps || Overlaps(c.b, c.a) != c.overlaps {
+			t.Errorf("Overlaps(%q, %q) should be %v", c.a, c.b, c.overlaps)
+		}
+	}
+}
+
+func TestOverlapsProperties(t *testing.T) {
+	// a pattern overlaps every name it matches
+	matched := func(pattern, event name) bool {
+		return !Match(string(pattern), string(event)) || Overlaps(string(pattern), string(event))
+	}
+	if err := quick.Check(matched, &quick.Config{MaxCount: 20000}); err != nil {
+		t.Error("a pattern doesn't overlap a name it matches:", err)
+	}
+}
+
+func TestCountMatching(t *testing.T) {
+	emitter := New()
+	fn := func(args ...interface{}) {}
+	emitter.On("user.created", fn).On("user.removed", fn).On("*.created", fn).On("order.paid", fn)
+	emitter.OnPatterns([]string{"user.updated", "user.renamed"}, fn)
+
+	expect(t, 4, emitter.CountMatching("user.*"), "the patterns of a subscription count once")
+	expect(t, 2, emitter.CountMatching("user.created"))
+	expect(t, 2, emitter.CountMatching("order.*"), "order.created would reach *.created")
+	expect(t, 1, emitter.CountMatching("invoice.*"))
+	expect(t, 0, emitter.CountMatching("invoice.paid"))
+	expect(t, 5, emitter.CountMatching("**"))
+
+	amqp := New(WithMatcher(AMQP))
+	amqp.On("user.#", fn).On("user.created", fn).On("order.*", fn)
+	expect(t, 2, amqp.CountMatching("user.*"))
+}
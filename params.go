@@ -0,0 +1,77 @@
+package Emitter
+
+import (
+	"regexp"
+	"strings"
+)
+
+// paramToken - a named parameter of a pattern, i.e "{id}" in "user.{id}.updated"
+var paramToken = regexp.MustCompile(`\{[A-Za-z_][A-Za-z0-9_]*\}`)
+
+// hasParams() - whether the pattern has named parameters
+func hasParams(pattern string) bool {
+	return strings.Contains(pattern, "{") && paramToken.MatchString(pattern)
+}
+
+// isPattern() - whether the event is a pattern rather than an exact name
+func isPattern(event string) bool {
+	return strings.Contains(event, "*") || hasParams(event)
+}
+
+// paramPattern() - the regexp of the parameterized pattern, a parameter matches a non empty run of
+// characters without ".", the stars keep their meaning; nil if the pattern has no parameters. The
+// emitters keep it in their compiled patterns
+func paramPattern(pattern string) *regexp.Regexp {
+	if !hasParams(pattern) {
+		return nil
+	}
+	expr := &strings.Builder{}
+	expr.WriteString("^")
+	last := 0
+	for _, loc := range paramToken.FindAllStringIndex(pattern, -1) {
+		expr.WriteString(globExpr(pattern[last:loc[0]]))
+		expr.WriteString("(?P<" + pattern[loc[0]+1:loc[1]-1] + ">[^.]+)")
+		last = loc[1]
+	}
+	expr.WriteString(globExpr(pattern[last:]))
+	expr.WriteString("$")
+	return regexp.MustCompile(expr.String())
+}
+
+// globExpr() - the regexp of a literal part of a pattern, its stars matching any run of characters
+func globExpr(part string) string {
+	literals := strings.Split(part, "*")
+	for i, literal := range literals {
+		literals[i] = regexp.QuoteMeta(literal)
+	}
+	return strings.Join(literals, "(?s:.*)")
+}
+
+// Params() - the values of the named parameters of the pattern in the event name, nil if it doesn't
+// match or has no parameters: Params("user.{id}.updated", "user.42.updated") is {"id": "42"}
+func Params(pattern, event string) map[string]string {
+	return paramsOf(paramPattern(pattern), event)
+}
+
+// paramsOf() - the values of the named parameters of the compiled pattern in the event name
+func paramsOf(re *regexp.Regexp, event string) map[string]string {
+	if re == nil {
+		return nil
+	}
+	values := re.FindStringSubmatch(event)
+	if values == nil {
+		return nil
+	}
+	params := make(map[string]string, len(values)-1)
+	for i, param := range re.SubexpNames() {
+		if param != "" {
+			params[param] = values[i]
+		}
+	}
+	return params
+}
+
+// Param() - the value of the named parameter of the listener's pattern, "" if it has none
+func (self *Event) Param(name string) string {
+	return self.Params[name]
+}
@@ -0,0 +1,36 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestParams(t *testing.T) {
+	expect(t, "42", Params("user.{id}.updated", "user.42.updated")["id"])
+	expect(t, true, Params("user.{id}.updated", "user.4.2.updated") == nil, "a parameter doesn't span the separators")
+	expect(t, true, Params("user.{id}.updated", "user..updated") == nil, "nor is empty")
+	expect(t, "eu", Params("{region}.order.*", "eu.order.item.added")["region"])
+	expect(t, true, Params("user.*", "user.created") == nil, "no parameters")
+
+	params := Params("shop.{shop}.order.{order}", "shop.s1.order.o2")
+	expect(t, 2, len(params))
+	expect(t, "o2", params["order"])
+}
+
+func TestParameterizedListeners(t *testing.T) {
+	emitter := New()
+	received := []*Event{}
+	emitter.OnEvent("user.{id}.updated", func(ev *Event) { received = append(received, ev) })
+	emitter.OnEvent("user.*", func(ev *Event) { received = append(received, ev) })
+
+	emitter.EmitSync("user.42.updated", "name")
+	expect(t, 2, len(received))
+	expect(t, "42", received[0].Param("id"))
+	expect(t, "user.{id}.updated", received[0].Pattern)
+	expect(t, "name", received[0].Args[0])
+	expect(t, true, received[1].Params == nil, "the other listeners get no parameters")
+
+	emitter.EmitSync("user.42.profile.updated")
+	expect(t, 3, len(received), "only the wildcard listener matches")
+
+	expect(t, ErrWildcard, New(WithExactMatchOnly()).CheckListen("user.{id}"))
+}
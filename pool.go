@@ -0,0 +1,243 @@
+package Emitter
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// the usual priorities of the emits, any int can be used
+const (
+	PriorityLow      = -10
+	PriorityNormal   = 0
+	PriorityHigh     = 10
+	PriorityCritical = 100
+)
+
+// Pool - a count of goroutines, see Resize, running the async listener invocations of the emitters
+// sharing it, capping the goroutines processing events however many emitters exist
+type Pool struct {
+	size    int
+	workers int
+	busy    int
+	queue   taskQueue
+	seq     uint64
+	closed  bool
+	// clock - the time source of the queueing times, see SetClock
+	clock Clock
+	mutex *sync.Mutex
+	cond  *sync.Cond
+	wg    *sync.WaitGroup
+}
+
+// NewPool() - create a new pool of size goroutines, at least 1
+func NewPool(size int) *Pool {
+	if size < 1 {
+		size = 1
+	}
+	pool := &Pool{size: size, workers: size, mutex: &sync.Mutex{}, wg: &sync.WaitGroup{}}
+	pool.cond = sync.NewCond(pool.mutex)
+	pool.wg.Add(size)
+	for i := 0; i < size; i++ {
+		go pool.work()
+	}
+	return pool
+}
+
+// WithPool() - run the async listener invocations of the emitter in the pool instead of their own goroutines,
+// the pool takes the emitter's clock, see WithClock, unless it has one already
+func WithPool(pool *Pool) Option {
+	return func(e *Emitter) {
+		e.pool = pool
+	}
+}
+
+// Submit() - queue the task to run on the next free goroutine, false if the pool is closed
+func (self *Pool) Submit(task func()) bool {
+	return self.SubmitPriority(task, PriorityNormal)
+}
+
+// SubmitPriority() - queue the task ahead of the ones of lower priority, after the ones of the
+// same priority, false if the pool is closed
+func (self *Pool) SubmitPriority(task func(), priority int) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closed {
+		return false
+	}
+	self.seq++
+	heap.Push(&self.queue, poolTask{run: task, priority: priority, seq: self.seq, queued: self.now()})
+	self.cond.Signal()
+	return true
+}
+
+// Size() - the count of goroutines of the pool
+func (self *Pool) Size() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.size
+}
+
+// Resize() - grow or shrink the pool to size goroutines, at least 1, the busy ones completing their task first
+func (self *Pool) Resize(size int) {
+	if size < 1 {
+		size = 1
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.closed {
+		return
+	}
+	self.size = size
+	for ; self.workers < size; self.workers++ {
+		self.wg.Add(1)
+		go self.work()
+	}
+	self.cond.Broadcast()
+}
+
+// Busy() - the count of goroutines running a task
+func (self *Pool) Busy() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.busy
+}
+
+// Pending() - the count of tasks waiting for a free goroutine
+func (self *Pool) Pending() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return len(self.queue)
+}
+
+// Waiting() - how long the oldest pending task has been waiting for a free goroutine, 0 if none is
+func (self *Pool) Waiting() time.Duration {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	var oldest time.Time
+	for _, task := range self.queue {
+		if oldest.IsZero() || task.queued.Before(oldest) {
+			oldest = task.queued
+		}
+	}
+	if oldest.IsZero() {
+		return 0
+	}
+	return self.now().Sub(oldest)
+}
+
+// SetClock() - use the clock as the time source of the queueing times, see Waiting
+func (self *Pool) SetClock(clock Clock) *Pool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.clock = clock
+	return self
+}
+
+// adoptClock() - use the emitter's clock unless the pool has one already
+func (self *Pool) adoptClock(clock Clock) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.clock == nil {
+		self.clock = clock
+	}
+}
+
+// now() - the time of the pool's clock, called with the mutex held
+func (self *Pool) now() time.Time {
+	if self.clock == nil {
+		return SystemClock.Now()
+	}
+	return self.clock.Now()
+}
+
+// saturation() - the count of pending tasks while all the goroutines are busy, 0 if one is free
+func (self *Pool) saturation() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.busy < self.size {
+		return 0
+	}
+	return len(self.queue)
+}
+
+// Closed() - whether the pool stopped accepting tasks
+func (self *Pool) Closed() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.closed
+}
+
+// Close() - stop accepting tasks and wait for the queued ones to run
+func (self *Pool) Close() {
+	self.mutex.Lock()
+	self.closed = true
+	self.cond.Broadcast()
+	self.mutex.Unlock()
+
+	self.wg.Wait()
+}
+
+// work() - run the queued tasks until the pool is closed and drained
+func (self *Pool) work() {
+	defer self.wg.Done()
+
+	for {
+		self.mutex.Lock()
+		for len(self.queue) == 0 && !self.closed && self.workers <= self.size {
+			self.cond.Wait()
+		}
+		// retired by Resize, or closed and drained
+		if self.workers > self.size || len(self.queue) == 0 {
+			self.workers--
+			self.mutex.Unlock()
+			return
+		}
+		task := heap.Pop(&self.queue).(poolTask)
+		self.busy++
+		self.mutex.Unlock()
+
+		task.run()
+
+		self.mutex.Lock()
+		self.busy--
+		self.mutex.Unlock()
+	}
+}
+
+// poolTask - a queued task, run by priority then in the order of submission
+type poolTask struct {
+	run      func()
+	priority int
+	seq      uint64
+	queued   time.Time
+}
+
+// taskQueue - the heap of the queued tasks
+type taskQueue []poolTask
+
+func (self taskQueue) Len() int { return len(self) }
+
+func (self taskQueue) Less(i, j int) bool {
+	if self[i].priority != self[j].priority {
+		return self[i].priority > self[j].priority
+	}
+	return self[i].seq < self[j].seq
+}
+
+func (self taskQueue) Swap(i, j int) { self[i], self[j] = self[j], self[i] }
+
+func (self *taskQueue) Push(x interface{}) { *self = append(*self, x.(poolTask)) }
+
+func (self *taskQueue) Pop() interface{} {
+	old := *self
+	task := old[len(old)-1]
+	old[len(old)-1] = poolTask{}
+	*self = old[:len(old)-1]
+	return task
+}
@@ -0,0 +1,86 @@
+package Emitter
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSharedPool(t *testing.T) {
+	pool := NewPool(3)
+	e1, e2 := New(WithPool(pool)), New(WithPool(pool))
+
+	var running, max int32
+	wg := sync.WaitGroup{}
+	listener := func(args ...interface{}) {
+		defer wg.Done()
+		n := atomic.AddInt32(&running, 1)
+		for m := atomic.LoadInt32(&max); n > m && !atomic.CompareAndSwapInt32(&max, m, n); m = atomic.LoadInt32(&max) {
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+	}
+	e1.On("a", listener).On("a", listener)
+	e2.On("b", listener)
+
+	wg.Add(15)
+	for i := 0; i < 5; i++ {
+		e1.EmitAsync("a", nil)
+		e2.EmitAsync("b", nil)
+	}
+	wg.Wait()
+	expect(t, true, atomic.LoadInt32(&max) <= 3)
+
+	pool.Close()
+	expect(t, false, pool.Submit(func() {}))
+
+	// a closed pool doesn't lose the events
+	wg.Add(1)
+	e2.EmitAsync("b", nil)
+	wg.Wait()
+}
+
+func TestPoolPriority(t *testing.T) {
+	pool := NewPool(1)
+	emitter := New(WithPool(pool))
+
+	block := make(chan struct{})
+	order := []string{}
+	emitter.On("block", func(args ...interface{}) { <-block })
+	emitter.OnEvent("**", func(ev *Event) {
+		if !IsMetaEvent(ev.Name) && ev.Name != "block" {
+			order = append(order, ev.Name)
+		}
+	})
+
+	emitter.EmitAsync("block", nil)
+	for pool.Busy() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	emitter.EmitAsyncPriority("bulk", PriorityLow)
+	emitter.EmitAsync("normal", nil)
+	emitter.EmitAsyncPriority("shutdown", PriorityCritical)
+	close(block)
+	pool.Close()
+
+	expect(t, "shutdown,normal,bulk", strings.Join(order, ","))
+}
+
+func TestPoolWaiting(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	pool := NewPool(1)
+	defer pool.Close()
+	New(WithPool(pool), WithClock(clock))
+
+	release := make(chan bool)
+	pool.Submit(func() { <-release })
+	for pool.Busy() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	pool.Submit(func() {})
+	clock.Advance(time.Minute)
+	expect(t, time.Minute, pool.Waiting(), "the pool takes the emitter's clock")
+	close(release)
+}
@@ -0,0 +1,44 @@
+package Emitter
+
+// SubscriberOnly - the listening side of an emitter, for the untrusted or third-party components:
+// they can't emit, wipe the listeners nor get to the emitter behind it
+type SubscriberOnly struct {
+	emitter *Emitter
+}
+
+// ReadOnly() - the view of the emitter exposing its subscription methods only
+func (self *Emitter) ReadOnly() SubscriberOnly {
+	return SubscriberOnly{self}
+}
+
+// On() - register a new listener on the specified event
+func (self SubscriberOnly) On(event string, callback func(...interface{})) SubscriberOnly {
+	self.emitter.On(event, callback)
+	return self
+}
+
+// Once() - register a new one-time listener on the specified event
+func (self SubscriberOnly) Once(event string, callback func(...interface{})) SubscriberOnly {
+	self.emitter.Once(event, callback)
+	return self
+}
+
+// OnEvent() - register a new listener receiving the whole event envelope on the specified event
+func (self SubscriberOnly) OnEvent(event string, handler func(*Event)) *Subscription {
+	return self.emitter.OnEvent(event, handler)
+}
+
+// OnPatterns() - register the callback on several patterns at once, see Emitter.OnPatterns
+func (self SubscriberOnly) OnPatterns(patterns []string, callback func(...interface{})) *Subscription {
+	return self.emitter.OnPatterns(patterns, callback)
+}
+
+// Subscribe() - register a new listener on the specified event, its subscription removes it
+func (self SubscriberOnly) Subscribe(event string, callback func(...interface{})) *Subscription {
+	return self.emitter.Subscribe(event, callback)
+}
+
+// SubscribeOnce() - register a new one-time listener on the specified event, its subscription removes it
+func (self SubscriberOnly) SubscribeOnce(event string, callback func(...interface{})) *Subscription {
+	return self.emitter.SubscribeOnce(event, callback)
+}
@@ -0,0 +1,24 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	emitter := New()
+	var source EventSource = emitter.ReadOnly()
+	plugin := emitter.ReadOnly()
+
+	calls := 0
+	plugin.On("user.created", func(args ...interface{}) { calls++ }).
+		Once("user.created", func(args ...interface{}) { calls += 10 })
+	sub := plugin.Subscribe("user.created", func(args ...interface{}) { calls += 100 })
+	source.OnEvent("user.created", func(ev *Event) { calls += 1000 })
+
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("user.created")
+	expect(t, 2212, calls)
+
+	sub.Remove()
+	expect(t, 2, emitter.ListenersCount("user.created"), "its own subscriptions can be removed")
+}
@@ -0,0 +1,121 @@
+package Emitter
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+)
+
+// ErrNotReconfigurable - returned by Reconfigure for the options that can't be applied to a live emitter
+var ErrNotReconfigurable = errors.New("emitter: option can't be applied to a live emitter, see Reconfigure")
+
+// WithSampling() - sample the emits of the patterns at their rate, as SetSampling does, replacing
+// the emitter's sampling
+func WithSampling(rates map[string]float64) Option {
+	return func(e *Emitter) {
+		e.samplers = []*sampler{}
+		for pattern, rate := range rates {
+			if rate < 1 {
+				e.samplers = append(e.samplers, &sampler{pattern: pattern, rate: rate})
+			}
+		}
+	}
+}
+
+// WithMuted() - mute the patterns, as Mute does, replacing the emitter's mutes
+func WithMuted(patterns ...string) Option {
+	return func(e *Emitter) {
+		e.mutes = []*mute{}
+		for _, pattern := range patterns {
+			e.mutes = append(e.mutes, &mute{pattern: pattern})
+		}
+	}
+}
+
+// WithConcurrency() - limit the simultaneous async invocations of the patterns, as SetConcurrency does,
+// replacing the emitter's limits
+func WithConcurrency(limits map[string]int) Option {
+	return func(e *Emitter) {
+		e.limits = []*concurrencyLimit{}
+		for pattern, n := range limits {
+			if n > 0 {
+				e.limits = append(e.limits, newConcurrencyLimit(pattern, n))
+			}
+		}
+	}
+}
+
+// WithPoolSize() - run the async invocations in a pool of size goroutines, resizing the emitter's pool
+// if it has one
+func WithPoolSize(size int) Option {
+	return func(e *Emitter) {
+		e.poolSize = size
+	}
+}
+
+// Reconfigure() - apply the options safe to change on a live emitter: WithSampling, WithMuted,
+// WithConcurrency and WithPoolSize (once the emitter has a pool). So the operators can tune a live
+// bus without restarting the service. Any other option fails with ErrNotReconfigurable and none of
+// them is applied
+func (self *Emitter) Reconfigure(opts ...Option) error {
+	// staged on a bare emitter, so they don't emit, take a serial or register anything
+	staged := staging()
+	for _, opt := range opts {
+		if !reconfigurable(opt) {
+			return ErrNotReconfigurable
+		}
+		opt(staged)
+	}
+
+	self.mutex.Lock()
+	if staged.poolSize > 0 && self.pool == nil {
+		self.mutex.Unlock()
+		return ErrNotReconfigurable
+	}
+	if staged.samplers != nil {
+		self.samplers = staged.samplers
+		for _, s := range self.samplers {
+			s.pattern = self.key(s.pattern)
+		}
+	}
+	if staged.mutes != nil {
+		// the patterns still muted keep their count
+		counts := map[string]*mute{}
+		for _, m := range self.mutes {
+			counts[m.pattern] = m
+		}
+		self.mutes = staged.mutes
+		for i, m := range self.mutes {
+			m.pattern = self.key(m.pattern)
+			if kept, ok := counts[m.pattern]; ok {
+				self.mutes[i] = kept
+			}
+		}
+	}
+	if staged.limits != nil {
+		self.limits = staged.limits
+		for _, limit := range self.limits {
+			limit.pattern = self.key(limit.pattern)
+		}
+	}
+	pool := self.pool
+	self.mutex.Unlock()
+
+	if staged.poolSize > 0 && pool != nil {
+		pool.Resize(staged.poolSize)
+	}
+	return nil
+}
+
+// staging() - a bare emitter to stage the options on
+func staging() *Emitter {
+	return &Emitter{listeners: make(map[interface{}][]Listener), mutex: &sync.Mutex{}}
+}
+
+// reconfigurable() - whether the option only sets what Reconfigure applies
+func reconfigurable(opt Option) bool {
+	staged := staging()
+	opt(staged)
+	staged.samplers, staged.mutes, staged.limits, staged.poolSize = nil, nil, nil, 0
+	return reflect.DeepEqual(staged, staging())
+}
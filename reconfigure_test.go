@@ -0,0 +1,85 @@
+package Emitter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReconfigure(t *testing.T) {
+	emitter := New(WithPoolSize(1), WithMuted("cache.*"))
+	delivered := 0
+	emitter.On("cache.hit", func(args ...interface{}) { delivered++ })
+	emitter.On("user.created", func(args ...interface{}) { delivered++ })
+	emitter.On("order.created", func(args ...interface{}) { delivered++ })
+	expect(t, 1, emitter.pool.Size())
+
+	emitter.EmitSync("cache.hit")
+	emitter.EmitSync("user.created")
+	expect(t, 1, delivered)
+
+	serial := nextSerial()
+	err := emitter.Reconfigure(WithMuted("user.*"), WithListener("user.created", func(args ...interface{}) { delivered += 10 }))
+	expect(t, ErrNotReconfigurable, err, "the unsafe options are rejected")
+	emitter.EmitSync("user.created")
+	expect(t, 2, delivered, "nothing is applied")
+	expect(t, serial+1, nextSerial(), "staging doesn't take a serial")
+
+	err = emitter.Reconfigure(WithMuted("user.*", "cache.*"), WithSampling(map[string]float64{"order.*": 0}), WithPoolSize(4))
+	expect(t, nil, err)
+	emitter.EmitSync("cache.hit")
+	emitter.EmitSync("user.created")
+	expect(t, 2, delivered, "the mutes are replaced")
+	expect(t, uint64(2), emitter.Muted()["cache.*"], "the patterns still muted keep their count")
+	expect(t, 4, emitter.pool.Size())
+
+	emitter.Reconfigure(WithMuted())
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("order.created")
+	expect(t, 3, delivered, "the sampling is kept")
+
+	expect(t, ErrNotReconfigurable, New().Reconfigure(WithPoolSize(4)), "no pool to resize")
+}
+
+func TestPoolResize(t *testing.T) {
+	pool := NewPool(1)
+	defer pool.Close()
+
+	release := make(chan bool)
+	started := make(chan bool, 4)
+	for i := 0; i < 4; i++ {
+		pool.Submit(func() {
+			started <- true
+			<-release
+		})
+	}
+	<-started
+	pool.Resize(4)
+	for i := 0; i < 3; i++ {
+		<-started
+	}
+	expect(t, 4, pool.Busy(), "the pool grew")
+
+	pool.Resize(2)
+	close(release)
+	wg := &sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		pool.Submit(func() {
+			defer wg.Done()
+			time.Sleep(time.Millisecond)
+		})
+	}
+	wg.Wait()
+
+	workers := 0
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		pool.mutex.Lock()
+		workers = pool.workers
+		pool.mutex.Unlock()
+		if workers == 2 {
+			break
+		}
+	}
+	expect(t, 2, workers, "the pool shrank once the tasks completed")
+}
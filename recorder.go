@@ -0,0 +1,140 @@
+package Emitter
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RecordedEmit - an emit captured by a Recorder
+type RecordedEmit struct {
+	Seq  uint64    `json:"seq"`
+	Time time.Time `json:"time"`
+	// Goroutine - the id of the goroutine that emitted it
+	Goroutine uint64            `json:"goroutine"`
+	Name      string            `json:"name"`
+	Args      []interface{}     `json:"args"`
+	Headers   map[string]string `json:"headers,omitempty"`
+}
+
+// Recording - the emits captured by a Recorder, in the order they happened
+type Recording struct {
+	Emits []RecordedEmit `json:"emits"`
+}
+
+// Recorder - captures the full sequence of emits of an emitter, to reproduce the race dependent
+// bugs by replaying it deterministically; it has a cost on each emit so it's meant for debugging
+type Recorder struct {
+	emitter *Emitter
+	emits   []RecordedEmit
+	mutex   *sync.Mutex
+}
+
+// Record() - capture the emits from now on, until the recorder is stopped, the meta-events excepted
+func (self *Emitter) Record() *Recorder {
+	recorder := &Recorder{emitter: self, mutex: &sync.Mutex{}}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.recorders = append(self.recorders, recorder)
+	return recorder
+}
+
+// Stop() - stop capturing the emits and return the recording
+func (self *Recorder) Stop() *Recording {
+	e := self.emitter
+	e.mutex.Lock()
+	for i, recorder := range e.recorders {
+		if recorder == self {
+			e.recorders = append(e.recorders[:i:i], e.recorders[i+1:]...)
+			break
+		}
+	}
+	e.mutex.Unlock()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return &Recording{Emits: append([]RecordedEmit{}, self.emits...)}
+}
+
+// capture() - append the event to the recording
+func (self *Recorder) capture(ev *Event, now time.Time, goroutine uint64) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.emits = append(self.emits, RecordedEmit{
+		Seq:       uint64(len(self.emits) + 1),
+		Time:      now,
+		Goroutine: goroutine,
+		Name:      ev.Name,
+		Args:      ev.Args,
+		Headers:   ev.Headers,
+	})
+}
+
+// record() - capture the event in the emitter's recorders
+func (self *Emitter) record(ev *Event) {
+	if IsMetaEvent(ev.Name) {
+		return
+	}
+
+	self.mutex.Lock()
+	recorders := self.recorders
+	self.mutex.Unlock()
+
+	if len(recorders) == 0 {
+		return
+	}
+	now, goroutine := self.Clock().Now(), goroutineID()
+	for _, recorder := range recorders {
+		recorder.capture(ev, now, goroutine)
+	}
+}
+
+// Replay() - emit the recorded events into the emitter one after the other, in their recorded
+// order and in synchronous mode, waiting between them the recorded delays divided by speed on the emitter's clock:
+// 1 replays them at the original speed, 10 ten times faster, 0 without waiting
+func (self *Recording) Replay(e *Emitter, speed float64) {
+	for i, emit := range self.Emits {
+		if i > 0 && speed > 0 {
+			e.sleep(time.Duration(float64(emit.Time.Sub(self.Emits[i-1].Time)) / speed))
+		}
+		e.EmitEvent(&Event{Name: emit.Name, Args: emit.Args, Headers: emit.Headers})
+	}
+}
+
+// WriteTo() - write the recording as json, the args are replayed then as json decodes them
+func (self *Recording) WriteTo(w io.Writer) (int64, error) {
+	data, err := json.Marshal(self)
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadRecording() - read a recording written by WriteTo
+func ReadRecording(r io.Reader) (*Recording, error) {
+	recording := &Recording{}
+	if err := json.NewDecoder(r).Decode(recording); err != nil {
+		return nil, err
+	}
+	return recording, nil
+}
+
+// goroutineID() - the id of the current goroutine, parsed from its stack header "goroutine 42 [running]:"
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i > 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
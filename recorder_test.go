@@ -0,0 +1,54 @@
+package Emitter
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRecordReplay(t *testing.T) {
+	emitter := Construct()
+	recorder := emitter.Record()
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		emitter.EmitSync("a", 1)
+	}()
+	go func() {
+		defer wg.Done()
+		time.Sleep(5 * time.Millisecond)
+		emitter.EmitSync("b", 2)
+	}()
+	wg.Wait()
+	recording := recorder.Stop()
+	emitter.EmitSync("c")
+
+	expect(t, 2, len(recording.Emits))
+	expect(t, true, recording.Emits[0].Goroutine != recording.Emits[1].Goroutine)
+	expect(t, true, recording.Emits[0].Goroutine != 0)
+
+	buf := &bytes.Buffer{}
+	recording.WriteTo(buf)
+	read, err := ReadRecording(buf)
+	expect(t, nil, err)
+
+	fresh := Construct()
+	names := []string{}
+	fresh.OnEvent("**", func(ev *Event) {
+		if !IsMetaEvent(ev.Name) {
+			names = append(names, ev.Name)
+		}
+	})
+	start := time.Now()
+	read.Replay(fresh, 1)
+	expect(t, "a,b", strings.Join(names, ","))
+	expect(t, true, time.Since(start) >= 4*time.Millisecond, "the original delays")
+
+	start = time.Now()
+	read.Replay(fresh, 0)
+	expect(t, true, time.Since(start) < 4*time.Millisecond)
+}
@@ -0,0 +1,289 @@
+// Package redisbridge publishes the events of an emitter to Redis channels and
+// emits the messages received on them locally.
+//
+// Event names are mapped to channels by prepending the bridge's prefix and the
+// emitter's patterns are subscribed with PSUBSCRIBE, whose glob `*` has the
+// same meaning. The bridge doesn't depend on a Redis client, adapt yours to the
+// Client/PubSub interfaces:
+//
+//	type redisClient struct{ *redis.Client }
+//
+//	func (c redisClient) Publish(channel string, data []byte) error {
+//		return c.Client.Publish(context.Background(), channel, data).Err()
+//	}
+//
+//	func (c redisClient) PSubscribe(patterns ...string) (redisbridge.PubSub, error) {
+//		ps := c.Client.PSubscribe(context.Background(), patterns...)
+//		_, err := ps.Receive(context.Background())
+//		return redisPubSub{ps}, err
+//	}
+//
+//	type redisPubSub struct{ *redis.PubSub }
+//
+//	func (ps redisPubSub) Receive() (string, []byte, error) {
+//		msg, err := ps.ReceiveMessage(context.Background())
+//		if err != nil {
+//			return "", nil, err
+//		}
+//		return msg.Channel, []byte(msg.Payload), nil
+//	}
+package redisbridge
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+	"github.com/moleculer-go/goemitter/codec"
+	"github.com/moleculer-go/goemitter/internal/bridge"
+)
+
+// Client - the Redis connection used by the bridge
+type Client interface {
+	Publish(channel string, data []byte) error
+	PSubscribe(patterns ...string) (PubSub, error)
+}
+
+// PubSub - a pattern subscription of the Redis connection
+type PubSub interface {
+	// Receive - block until the next message, an error ends the subscription
+	Receive() (channel string, data []byte, err error)
+	Close() error
+}
+
+// ErrStarted - returned by Start once the bridge was started
+var ErrStarted = errors.New("redisbridge: already started")
+
+// Bridge - publishes the events matching its patterns to Redis and emits the received ones
+type Bridge struct {
+	// Codec - the payload serialization, codec.JSON typed by the emitter's schemas by default
+	Codec codec.Codec
+	// Prefix - prepended to the event names to build the channels
+	Prefix string
+	// OnError - called with the errors of publishing/decoding/subscribing, if set
+	OnError func(err error)
+	// Backoff - the delay before the first resubscription attempt, doubled up to MaxBackoff
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+	// Relay - also publish the events received from other bridges, through MaxHops bridges at most
+	// and never back through a bridge they went through, see Emitter.Forwards
+	Relay   bool
+	MaxHops int
+
+	id       string
+	emitter  *Emitter.Emitter
+	client   Client
+	patterns []string
+	listener *Emitter.Subscription
+	pubsub   PubSub
+	started  bool
+	closed   chan struct{}
+	done     chan struct{}
+	mutex    *sync.Mutex
+}
+
+// New() - create a new bridge between the emitter and the Redis client
+func New(e *Emitter.Emitter, client Client) *Bridge {
+	return &Bridge{
+		Codec:      codec.Typed(e, codec.JSON),
+		Prefix:     "events.",
+		Backoff:    100 * time.Millisecond,
+		MaxBackoff: 30 * time.Second,
+		MaxHops:    Emitter.DefaultMaxHops,
+		id:         bridge.NewID(),
+		emitter:    e,
+		client:     client,
+		closed:     make(chan struct{}),
+		done:       make(chan struct{}),
+		mutex:      &sync.Mutex{},
+	}
+}
+
+// Attach() - create and start a new bridge for the events matching the specified patterns
+func Attach(e *Emitter.Emitter, client Client, patterns ...string) (*Bridge, error) {
+	bridge := New(e, client)
+	return bridge, bridge.Start(patterns...)
+}
+
+// ID() - the origin id stamped on the events published by the bridge
+func (self *Bridge) ID() string {
+	return self.id
+}
+
+// Start() - subscribe to the patterns and start publishing the matching local events,
+// once subscribed the bridge resubscribes by itself whenever the subscription breaks; a bridge is
+// started once, ErrStarted is returned afterwards
+func (self *Bridge) Start(patterns ...string) error {
+	self.mutex.Lock()
+	if self.started {
+		self.mutex.Unlock()
+		return ErrStarted
+	}
+	self.started = true
+	self.mutex.Unlock()
+
+	channels := make([]string, len(patterns))
+	for i, pattern := range patterns {
+		channels[i] = ChannelPattern(self.Prefix, pattern)
+	}
+
+	pubsub, err := self.client.PSubscribe(channels...)
+	if err != nil {
+		self.mutex.Lock()
+		self.started = false
+		self.mutex.Unlock()
+		return err
+	}
+
+	self.mutex.Lock()
+	self.patterns = patterns
+	self.pubsub = pubsub
+	self.mutex.Unlock()
+
+	listener := self.emitter.OnEvent("**", self.publish).Bridge()
+	self.mutex.Lock()
+	self.listener = listener
+	self.mutex.Unlock()
+
+	go self.receive(pubsub, channels)
+	return nil
+}
+
+// Close() - stop listening on the emitter and close the subscription
+func (self *Bridge) Close() error {
+	self.mutex.Lock()
+	select {
+	case <-self.closed:
+		self.mutex.Unlock()
+		return nil
+	default:
+	}
+	close(self.closed)
+	listener, pubsub := self.listener, self.pubsub
+	self.mutex.Unlock()
+
+	if listener != nil {
+		listener.Remove()
+	}
+	if pubsub == nil {
+		return nil
+	}
+	err := pubsub.Close()
+	<-self.done
+	return err
+}
+
+// receive() - emit the received events, resubscribing with backoff whenever receiving fails
+func (self *Bridge) receive(pubsub PubSub, channels []string) {
+	defer close(self.done)
+
+	backoff := self.Backoff
+	for {
+		channel, data, err := pubsub.Receive()
+		if err == nil {
+			backoff = self.Backoff
+			self.emit(channel, data)
+			continue
+		}
+
+		select {
+		case <-self.closed:
+			return
+		default:
+		}
+		bridge.Report(self.OnError, err)
+		pubsub.Close()
+
+		// reconnect until subscribed again or closed
+		for {
+			select {
+			case <-self.closed:
+				return
+			case <-time.After(backoff):
+			}
+			if backoff *= 2; backoff > self.MaxBackoff {
+				backoff = self.MaxBackoff
+			}
+
+			if pubsub, err = self.client.PSubscribe(channels...); err == nil {
+				break
+			}
+			bridge.Report(self.OnError, err)
+		}
+
+		self.mutex.Lock()
+		self.pubsub = pubsub
+		self.mutex.Unlock()
+
+		// closed while resubscribing, Close didn't get this subscription
+		select {
+		case <-self.closed:
+			pubsub.Close()
+			return
+		default:
+		}
+	}
+}
+
+// emit() - emit a received event locally, unless the bridge published it
+func (self *Bridge) emit(channel string, data []byte) {
+	ev, err := self.Codec.Unmarshal(data)
+	if err != nil {
+		bridge.Report(self.OnError, err)
+		return
+	}
+	if ev.Name == "" {
+		ev.Name = strings.TrimPrefix(channel, self.Prefix)
+	}
+	if ev.Visited(self.id) {
+		return
+	}
+	if ev.Headers[Emitter.HeaderOrigin] == "" {
+		// published by a foreign producer, mark it remote so it isn't published back
+		if ev.Headers == nil {
+			ev.Headers = map[string]string{}
+		}
+		ev.Headers[Emitter.HeaderOrigin] = channel
+	}
+	ev.Received(self.id)
+	self.emitter.EmitEvent(ev)
+}
+
+// publish() - publish the locally originated events matching the bridge's patterns
+func (self *Bridge) publish(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) || !Emitter.Forwards(ev, self.id, self.Relay, self.MaxHops) || !self.publishes(ev.Name) {
+		return
+	}
+
+	data, err := self.Codec.Marshal(ev.Forwarded(self.id))
+	if err == nil {
+		err = self.client.Publish(self.Prefix+ev.Name, data)
+	}
+	bridge.Report(self.OnError, err)
+}
+
+// publishes() - whether the event matches one of the bridge's patterns
+func (self *Bridge) publishes(event string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for _, pattern := range self.patterns {
+		if Emitter.Match(pattern, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// ChannelPattern() - translate an emitter pattern to a PSUBSCRIBE pattern
+func ChannelPattern(prefix, pattern string) string {
+	if pattern == "**" {
+		return globEscaper.Replace(prefix) + "*"
+	}
+	return globEscaper.Replace(prefix + pattern)
+}
+
+// globEscaper - escapes the glob characters that have no meaning in the emitter's patterns
+var globEscaper = strings.NewReplacer(`\`, `\\`, "?", `\?`, "[", `\[`, "]", `\]`)
@@ -0,0 +1,175 @@
+package redisbridge
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// fakeRedis - an in memory broker, the subscriptions can be broken to test resubscribing
+type fakeRedis struct {
+	subs       map[*fakePubSub]bool
+	subscribed int
+	mutex      sync.Mutex
+}
+
+type fakePubSub struct {
+	patterns []string
+	messages chan [2]string
+	once     sync.Once
+}
+
+func (self *fakePubSub) Receive() (string, []byte, error) {
+	msg, ok := <-self.messages
+	if !ok {
+		return "", nil, io.EOF
+	}
+	return msg[0], []byte(msg[1]), nil
+}
+
+func (self *fakePubSub) Close() error {
+	self.once.Do(func() { close(self.messages) })
+	return nil
+}
+
+func (self *fakeRedis) Publish(channel string, data []byte) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for sub := range self.subs {
+		for _, pattern := range sub.patterns {
+			if Emitter.Match(pattern, channel) {
+				sub.messages <- [2]string{channel, string(data)}
+			}
+		}
+	}
+	return nil
+}
+
+func (self *fakeRedis) PSubscribe(patterns ...string) (PubSub, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	sub := &fakePubSub{patterns: patterns, messages: make(chan [2]string, 10)}
+	self.subs[sub] = true
+	self.subscribed++
+	return sub, nil
+}
+
+// drop() - break all the subscriptions
+func (self *fakeRedis) drop() {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for sub := range self.subs {
+		sub.Close()
+		delete(self.subs, sub)
+	}
+}
+
+func (self *fakeRedis) subscriptions() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return len(self.subs)
+}
+
+func TestBridge(t *testing.T) {
+	redis := &fakeRedis{subs: map[*fakePubSub]bool{}}
+	e1, e2 := Emitter.Construct(), Emitter.Construct()
+
+	b1, _ := Attach(e1, redis, "user.*")
+	defer b1.Close()
+
+	b2 := New(e2, redis)
+	b2.Backoff = time.Millisecond
+	b2.OnError = func(err error) {}
+	b2.Start("user.*")
+
+	received := make(chan string, 10)
+	e2.On("user.created", func(args ...interface{}) {
+		received <- args[0].(string)
+	})
+
+	e1.EmitSync("user.created", "john")
+	e1.EmitSync("account.created", "john")
+	expect(t, "john", wait(t, received))
+
+	// break the subscriptions and wait for b2 to resubscribe
+	redis.drop()
+	for i := 0; i < 1000 && redis.subscriptions() < 2; i++ {
+		time.Sleep(time.Millisecond)
+	}
+
+	e1.EmitSync("user.created", "jane")
+	expect(t, "jane", wait(t, received))
+
+	b2.Close()
+	expect(t, 1, e2.ListenersCount("user.created"))
+}
+
+func TestForeignProducer(t *testing.T) {
+	redis := &fakeRedis{subs: map[*fakePubSub]bool{}}
+	emitter := Emitter.Construct()
+	bridge, _ := Attach(emitter, redis, "**")
+	defer bridge.Close()
+
+	received := make(chan string, 10)
+	emitter.OnEvent("user.created", func(ev *Emitter.Event) {
+		received <- ev.Headers[Emitter.HeaderOrigin]
+	})
+
+	redis.Publish("events.user.created", []byte(`{"args":["john"]}`))
+	expect(t, "events.user.created", wait(t, received))
+}
+
+func TestStartTwice(t *testing.T) {
+	redis := &fakeRedis{subs: map[*fakePubSub]bool{}}
+	emitter := Emitter.Construct()
+	bridge, _ := Attach(emitter, redis, "user.*")
+
+	expect(t, ErrStarted, bridge.Start("account.*"))
+	expect(t, 1, redis.subscriptions())
+	expect(t, 1, emitter.ListenersCount("user.created"))
+	expect(t, nil, bridge.Close())
+}
+
+func TestSubscribeError(t *testing.T) {
+	_, err := Attach(Emitter.Construct(), failingRedis{}, "**")
+	expect(t, "unavailable", err.Error())
+}
+
+type failingRedis struct{}
+
+func (failingRedis) Publish(string, []byte) error { return nil }
+
+func (failingRedis) PSubscribe(...string) (PubSub, error) {
+	return nil, errors.New("unavailable")
+}
+
+func TestChannelPattern(t *testing.T) {
+	expect(t, "events.*", ChannelPattern("events.", "**"))
+	expect(t, "events.user.*", ChannelPattern("events.", "user.*"))
+	expect(t, `events.what\?`, ChannelPattern("events.", "what?"))
+	expect(t, true, strings.HasSuffix(ChannelPattern("", "[x]"), `\]`))
+}
+
+func wait(t *testing.T, ch chan string) string {
+	select {
+	case v := <-ch:
+		return v
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+		return ""
+	}
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
@@ -0,0 +1,34 @@
+package Emitter
+
+// OnReduce() - register a new listener folding the event into an accumulator, EmitReduce passes
+// it the accumulator returned by the previous one while the other emits pass it a nil one
+func (self *Emitter) OnReduce(event string, reducer func(acc interface{}, args ...interface{}) interface{}) *Subscription {
+	return self.addListenerInternal(event, Listener{reduces: reducer})
+}
+
+// EmitReduce() - run the listeners of the event in synchronous mode, in their order, each reducer
+// receiving the accumulator returned by the previous one, starting from acc, and return the last
+// one, i.e for plugin hooks collectively building a result; the plain listeners run but keep it
+func (self *Emitter) EmitReduce(event string, acc interface{}, args ...interface{}) interface{} {
+	self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		n := 0
+		for _, v := range self.listenersOf(ev.Name) {
+			if v.once && !self.claimOnce(v) {
+				continue
+			}
+			n++
+			if reduces := v.reduces; reduces != nil {
+				v.reduces = func(_ interface{}, args ...interface{}) interface{} {
+					acc = reduces(acc, args...)
+					return acc
+				}
+			}
+			v.callWait(ev)
+		}
+		return n
+	})
+	return acc
+}
@@ -0,0 +1,42 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestEmitReduce(t *testing.T) {
+	emitter := Construct()
+
+	plain := 0
+	emitter.OnReduce("response.headers", func(acc interface{}, args ...interface{}) interface{} {
+		headers := acc.(map[string]string)
+		headers["X-Request"] = args[0].(string)
+		return headers
+	})
+	emitter.On("response.*", func(args ...interface{}) { plain++ })
+	emitter.OnReduce("response.*", func(acc interface{}, args ...interface{}) interface{} {
+		if acc == nil {
+			return nil
+		}
+		headers := acc.(map[string]string)
+		headers["X-Count"] = "2"
+		return headers
+	})
+
+	headers := emitter.EmitReduce("response.headers", map[string]string{}, "42").(map[string]string)
+	expect(t, 2, len(headers))
+	expect(t, "42", headers["X-Request"])
+	expect(t, 1, plain)
+
+	expect(t, 0, emitter.EmitReduce("nothing", 0))
+}
+
+func TestEmitReduceCall(t *testing.T) {
+	emitter := New()
+	sub := emitter.OnReduce("sum", func(acc interface{}, args ...interface{}) interface{} { return acc.(int) + args[0].(int) })
+	sub.update(func(l *Listener) { l.executor = ExecutorFunc(func(task func()) { go task() }) })
+	emitter.OnReduce("sum", func(acc interface{}, args ...interface{}) interface{} { return acc.(int) * 10 })
+
+	expect(t, 30, emitter.EmitReduce("sum", 1, 2), "the emit waits for the reducer's executor")
+	expect(t, uint64(1), sub.Stats().Calls)
+}
@@ -0,0 +1,75 @@
+package Emitter
+
+import (
+	"strings"
+)
+
+// HeaderVia - the header holding the comma separated ids of the bridges an event went through, the first one first,
+// both the ones forwarding it and the ones receiving it
+const HeaderVia = "via"
+
+// Via() - the ids of the bridges the event went through, the first one first,
+// only its origin if it was received before the bridges tracked them
+func (self *Event) Via() []string {
+	if via := self.Headers[HeaderVia]; via != "" {
+		return strings.Split(via, ",")
+	}
+	if origin := self.Headers[HeaderOrigin]; origin != "" {
+		return []string{origin}
+	}
+	return nil
+}
+
+// Hops() - the count of bridges the event went through
+func (self *Event) Hops() int {
+	return len(self.Via())
+}
+
+// Visited() - whether the event went through the bridge
+func (self *Event) Visited(id string) bool {
+	for _, via := range self.Via() {
+		if via == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Forwarded() - a copy of the event as forwarded by the bridge: its origin and the last of its via
+func (self *Event) Forwarded(id string) *Event {
+	out := *self
+	out.Pattern = ""
+	out.Headers = make(map[string]string, len(self.Headers)+2)
+	for k, v := range self.Headers {
+		out.Headers[k] = v
+	}
+	out.Headers[HeaderVia] = strings.Join(append(self.Via(), id), ",")
+	out.Headers[HeaderOrigin] = id
+	return &out
+}
+
+// Received() - record that the bridge received the event, so it isn't forwarded back through it
+func (self *Event) Received(id string) {
+	via := strings.Join(append(self.Via(), id), ",")
+	if self.Headers == nil {
+		self.Headers = map[string]string{}
+	}
+	self.Headers[HeaderVia] = via
+}
+
+// Forwards() - whether the bridge forwards the event: the locally originated ones always, the ones
+// received from other bridges only if relaying, through maxHops bridges at most (no limit if 0)
+// and never back through a bridge they went through already, so bridging loops end
+func Forwards(ev *Event, id string, relay bool, maxHops int) bool {
+	if ev.Headers[HeaderOrigin] == "" {
+		return true
+	}
+	if !relay || (maxHops > 0 && ev.Hops() >= maxHops) {
+		return false
+	}
+	return !ev.Visited(id)
+}
+
+// DefaultMaxHops - the count of bridges a relayed event goes through at most by default,
+// two per transport: the forwarding one and the receiving one
+const DefaultMaxHops = 16
@@ -0,0 +1,29 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestForwards(t *testing.T) {
+	ev := &Event{Name: "user.created"}
+	expect(t, true, Forwards(ev, "a", false, 0), "locally originated")
+
+	ev = ev.Forwarded("a")
+	expect(t, "a", ev.Headers[HeaderOrigin])
+	expect(t, false, Forwards(ev, "b", false, 0), "received, not relaying")
+	expect(t, true, Forwards(ev, "b", true, 0))
+
+	ev = ev.Forwarded("b")
+	expect(t, "a,b", strings.Join(ev.Via(), ","))
+	expect(t, 2, ev.Hops())
+	expect(t, false, Forwards(ev, "a", true, 0), "back to its source")
+	expect(t, false, Forwards(ev, "c", true, 2), "too many hops")
+	expect(t, true, Forwards(ev, "c", true, 3))
+
+	ev.Received("c")
+	expect(t, false, Forwards(ev, "c", true, 0), "back through the bridge it came from")
+
+	legacy := &Event{Name: "user.created", Headers: map[string]string{HeaderOrigin: "x"}}
+	expect(t, true, legacy.Visited("x"))
+}
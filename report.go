@@ -0,0 +1,238 @@
+package Emitter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reportBuckets - the slices of the report's window, the oldest one is dropped as the window slides
+const reportBuckets = 10
+
+// Report - the ranked summary of the emitter's activity over the last window, see TrackReport
+type Report struct {
+	Window time.Duration
+	// Emits - the most emitted events, the most frequent first
+	Emits []EventCount
+	// Slowest - the listeners with the highest mean run time, the slowest first
+	Slowest []ListenerReport
+	// Panics - the listeners which panicked the most, the most failing first
+	Panics []ListenerReport
+}
+
+// EventCount - the emits of an event in the report's window
+type EventCount struct {
+	Event string
+	Count uint64
+}
+
+// ListenerReport - the runs of a listener in the report's window
+type ListenerReport struct {
+	ID     SubscriptionID
+	Event  string
+	Calls  uint64
+	Panics uint64
+	Total  time.Duration
+	Max    time.Duration
+}
+
+// Mean() - the mean run time of the listener
+func (self ListenerReport) Mean() time.Duration {
+	if self.Calls == 0 {
+		return 0
+	}
+	return self.Total / time.Duration(self.Calls)
+}
+
+// String() - the report as a few lines suited to periodic logging
+func (self Report) String() string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "emitter: report of the last %v\n", self.Window)
+	for _, e := range self.Emits {
+		fmt.Fprintf(b, "  emitted %q %d times\n", e.Event, e.Count)
+	}
+	for _, l := range self.Slowest {
+		fmt.Fprintf(b, "  listener #%d of %q ran %d times, %v mean, %v max\n", l.ID, l.Event, l.Calls, l.Mean(), l.Max)
+	}
+	for _, l := range self.Panics {
+		fmt.Fprintf(b, "  listener #%d of %q panicked %d times out of %d\n", l.ID, l.Event, l.Panics, l.Calls)
+	}
+	return b.String()
+}
+
+// reporter - the activity of the emitter over the sliding window
+type reporter struct {
+	window  time.Duration
+	slice   time.Duration
+	top     int
+	buckets [reportBuckets]reportBucket
+	mutex   *sync.Mutex
+}
+
+// reportBucket - the activity during a slice of the window
+type reportBucket struct {
+	slice     int64
+	emits     map[string]uint64
+	listeners map[uint64]*ListenerReport
+}
+
+// TrackReport() - track the emits and the listeners' run times and panics over a window sliding with
+// the emitter's clock, for Report to rank the top ones; 0 stops tracking. It times every invocation
+// so it has a small cost on each of them
+func (self *Emitter) TrackReport(window time.Duration, top int) *Emitter {
+	if window <= 0 {
+		self.reporter.Store((*reporter)(nil))
+		return self
+	}
+	slice := window / reportBuckets
+	if slice <= 0 {
+		slice = 1
+	}
+	self.reporter.Store(&reporter{window: window, slice: slice, top: top, mutex: &sync.Mutex{}})
+	return self
+}
+
+// Report() - rank the activity of the tracked window, empty if not tracking
+func (self *Emitter) Report() Report {
+	r := self.reporting()
+	if r == nil {
+		return Report{}
+	}
+	return r.report(self.Clock().Now())
+}
+
+// reporting() - the reporter, nil if not tracking
+func (self *Emitter) reporting() *reporter {
+	r, _ := self.reporter.Load().(*reporter)
+	return r
+}
+
+// reportEmit() - count the emit of the event if tracking
+func (self *Emitter) reportEmit(ev *Event) {
+	if r := self.reporting(); r != nil && !IsMetaEvent(ev.Name) {
+		r.emitted(ev.Name, self.Clock().Now())
+	}
+}
+
+// timed() - record the run of the listener started at start once it returns, or panics
+func (self *reporter) timed(l Listener, clock Clock, start time.Time) {
+	err := recover()
+	now := clock.Now()
+	self.ran(l, now, now.Sub(start), err != nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// bucket() - the bucket of the time, reset if it held an older slice, the mutex must be held
+func (self *reporter) bucket(now time.Time) *reportBucket {
+	slice := now.UnixNano() / int64(self.slice)
+	b := &self.buckets[slice%reportBuckets]
+	if b.slice != slice || b.emits == nil {
+		*b = reportBucket{slice: slice, emits: map[string]uint64{}, listeners: map[uint64]*ListenerReport{}}
+	}
+	return b
+}
+
+func (self *reporter) emitted(event string, now time.Time) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.bucket(now).emits[event]++
+}
+
+func (self *reporter) ran(l Listener, now time.Time, elapsed time.Duration, panicked bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	b := self.bucket(now)
+	run := b.listeners[l.id]
+	if run == nil {
+		run = &ListenerReport{ID: l.ID(), Event: l.event}
+		b.listeners[l.id] = run
+	}
+	run.Calls++
+	run.Total += elapsed
+	if elapsed > run.Max {
+		run.Max = elapsed
+	}
+	if panicked {
+		run.Panics++
+	}
+}
+
+// report() - merge the buckets of the window and rank them
+func (self *reporter) report(now time.Time) Report {
+	self.mutex.Lock()
+	emits := map[string]uint64{}
+	runs := map[uint64]*ListenerReport{}
+	current := now.UnixNano() / int64(self.slice)
+	for _, b := range self.buckets {
+		if b.emits == nil || current-b.slice >= reportBuckets {
+			continue
+		}
+		for event, count := range b.emits {
+			emits[event] += count
+		}
+		for id, run := range b.listeners {
+			merged := runs[id]
+			if merged == nil {
+				merged = &ListenerReport{ID: run.ID, Event: run.Event}
+				runs[id] = merged
+			}
+			merged.Calls += run.Calls
+			merged.Panics += run.Panics
+			merged.Total += run.Total
+			if run.Max > merged.Max {
+				merged.Max = run.Max
+			}
+		}
+	}
+	self.mutex.Unlock()
+
+	report := Report{Window: self.window}
+	for event, count := range emits {
+		report.Emits = append(report.Emits, EventCount{event, count})
+	}
+	sort.Slice(report.Emits, func(i, j int) bool {
+		a, b := report.Emits[i], report.Emits[j]
+		return a.Count > b.Count || (a.Count == b.Count && a.Event < b.Event)
+	})
+
+	for _, run := range runs {
+		report.Slowest = append(report.Slowest, *run)
+		if run.Panics > 0 {
+			report.Panics = append(report.Panics, *run)
+		}
+	}
+	sort.Slice(report.Slowest, func(i, j int) bool {
+		a, b := report.Slowest[i], report.Slowest[j]
+		return a.Mean() > b.Mean() || (a.Mean() == b.Mean() && a.ID < b.ID)
+	})
+	sort.Slice(report.Panics, func(i, j int) bool {
+		a, b := report.Panics[i], report.Panics[j]
+		return a.Panics > b.Panics || (a.Panics == b.Panics && a.ID < b.ID)
+	})
+
+	if self.top > 0 {
+		report.Emits = truncateEmits(report.Emits, self.top)
+		report.Slowest = truncateRuns(report.Slowest, self.top)
+		report.Panics = truncateRuns(report.Panics, self.top)
+	}
+	return report
+}
+
+func truncateEmits(emits []EventCount, n int) []EventCount {
+	if len(emits) > n {
+		return emits[:n]
+	}
+	return emits
+}
+
+func truncateRuns(runs []ListenerReport, n int) []ListenerReport {
+	if len(runs) > n {
+		return runs[:n]
+	}
+	return runs
+}
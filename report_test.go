@@ -0,0 +1,56 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReport(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1000, 0))
+	emitter := New(WithClock(clock))
+	expect(t, 0, len(emitter.Report().Emits), "not tracking")
+	emitter.TrackReport(time.Minute, 2)
+
+	emitter.Subscribe("tick", func(args ...interface{}) {})
+	slow := emitter.Subscribe("tick", func(args ...interface{}) { clock.Set(clock.Now().Add(time.Second)) })
+	failing := emitter.Subscribe("boom", func(args ...interface{}) { panic("boom") })
+
+	for i := 0; i < 3; i++ {
+		emitter.EmitSync("tick")
+	}
+	emitter.EmitSync("tock")
+	for i := 0; i < 2; i++ {
+		func() {
+			defer func() {
+				expect(t, "boom", recover(), "the panic still propagates")
+			}()
+			emitter.EmitSync("boom")
+		}()
+	}
+
+	report := emitter.Report()
+	expect(t, time.Minute, report.Window)
+	expect(t, 2, len(report.Emits), "the top 2")
+	expect(t, EventCount{"tick", 3}, report.Emits[0])
+	expect(t, EventCount{"boom", 2}, report.Emits[1])
+
+	expect(t, slow.ID(), report.Slowest[0].ID)
+	expect(t, time.Second, report.Slowest[0].Mean())
+	expect(t, uint64(3), report.Slowest[0].Calls)
+	expect(t, true, report.Slowest[1].ID != slow.ID())
+	expect(t, 1, len(report.Panics))
+	expect(t, failing.ID(), report.Panics[0].ID)
+	expect(t, uint64(2), report.Panics[0].Panics)
+	expect(t, true, strings.Contains(report.String(), `emitted "tick" 3 times`))
+
+	// the window slides past the activity
+	clock.Advance(2 * time.Minute)
+	emitter.EmitSync("tick")
+	report = emitter.Report()
+	expect(t, EventCount{"tick", 1}, report.Emits[0])
+	expect(t, 0, len(report.Panics))
+
+	emitter.TrackReport(0, 0)
+	expect(t, 0, len(emitter.Report().Emits))
+}
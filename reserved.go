@@ -0,0 +1,145 @@
+package Emitter
+
+import (
+	"errors"
+)
+
+// ErrReserved - the event name is reserved, only its reservation can emit or listen on it
+var ErrReserved = errors.New("emitter: event name reserved")
+
+// Reservation - the owner of reserved event names, the only one able to emit and listen on them
+type Reservation struct {
+	emitter  *Emitter
+	patterns []string
+}
+
+// Reserve() - reserve the event names matching the patterns for internal use: emitting them or
+// listening on them through the emitter is rejected and reported by a "schemaViolation"
+// meta-event with ErrReserved, they're only usable through the returned reservation.
+// Once anything is reserved the emitter's own meta-events can't be emitted by user code either.
+func (self *Emitter) Reserve(patterns ...string) *Reservation {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	reservation := &Reservation{emitter: self}
+	for _, pattern := range patterns {
+		reservation.patterns = append(reservation.patterns, self.key(pattern))
+	}
+	self.reservations = append(self.reservations, reservation)
+	return reservation
+}
+
+// CheckEmit() - ErrReserved, or the error of the auth callback, if emitting the event through the emitter is rejected
+func (self *Emitter) CheckEmit(event string) error {
+	return self.checkName(event, true)
+}
+
+// CheckListen() - ErrReserved, ErrFrozen, ErrWildcard, or the error of the auth callback, if listening on the event through the emitter is rejected
+func (self *Emitter) CheckListen(event string) error {
+	if self.Frozen() {
+		return ErrFrozen
+	}
+	return self.checkName(event, false)
+}
+
+func (self *Emitter) checkName(event string, emit bool) error {
+	if !emit && self.exactOnly && isPattern(event) {
+		return ErrWildcard
+	}
+	if err := self.checkReserved(event, emit); err != nil {
+		return err
+	}
+	return self.authorize(event, emit)
+}
+
+func (self *Emitter) checkReserved(event string, emit bool) error {
+	if emit && IsLifecycleEvent(event) {
+		return ErrReserved
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if len(self.reservations) == 0 {
+		return nil
+	}
+	if emit && IsMetaEvent(event) {
+		return ErrReserved
+	}
+	for _, reservation := range self.reservations {
+		if reservation.owns(event) {
+			return ErrReserved
+		}
+	}
+	return nil
+}
+
+// reserved() - whether the emit or the registration is rejected, reserved or unauthorized, reporting it
+func (self *Emitter) reserved(event string, emit bool) bool {
+	err := self.checkName(event, emit)
+	if err == nil {
+		return false
+	}
+	self.emitMeta("schemaViolation", event, err)
+	return true
+}
+
+// owns() - whether the event name is reserved by the reservation, the emitter's mutex must be held
+func (self *Reservation) owns(event string) bool {
+	event = self.emitter.key(event)
+	for _, pattern := range self.patterns {
+		if self.emitter.compiled(pattern).match(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// allows() - whether the reservation may use the event name
+func (self *Reservation) allows(event string) bool {
+	self.emitter.mutex.Lock()
+	defer self.emitter.mutex.Unlock()
+	return self.owns(event)
+}
+
+// On() - register a new listener on the event, reserved or not
+func (self *Reservation) On(event string, callback func(...interface{})) *Subscription {
+	if !self.allows(event) {
+		return self.emitter.addListenerInternal(event, Listener{callback: callback})
+	}
+	return self.emitter.addListener(event, Listener{callback: callback})
+}
+
+// OnEvent() - register a new listener receiving the whole event envelope on the event, reserved or not
+func (self *Reservation) OnEvent(event string, handler func(*Event)) *Subscription {
+	if !self.allows(event) {
+		return self.emitter.addListenerInternal(event, Listener{handler: handler})
+	}
+	return self.emitter.addListener(event, Listener{handler: handler})
+}
+
+// EmitSync() - run all listeners of the event, reserved or not, in synchronous mode
+func (self *Reservation) EmitSync(event string, args ...interface{}) *Reservation {
+	return self.EmitEvent(&Event{Name: event, Args: args})
+}
+
+// EmitEvent() - run all listeners of the event envelope, reserved or not, in synchronous mode
+func (self *Reservation) EmitEvent(ev *Event) *Reservation {
+	if !self.allows(ev.Name) {
+		self.emitter.dispatch(ev, false)
+	} else {
+		self.emitter.deliver(ev, false)
+	}
+	return self
+}
+
+// EmitAsync() - run all listeners of the event, reserved or not, in asynchronous mode using goroutines
+func (self *Reservation) EmitAsync(event string, args []interface{}) *Reservation {
+	ev := &Event{Name: event, Args: args}
+	if !self.allows(event) {
+		self.emitter.dispatch(ev, true)
+	} else {
+		self.emitter.deliver(ev, true)
+	}
+	return self
+}
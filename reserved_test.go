@@ -0,0 +1,35 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestReserve(t *testing.T) {
+	emitter := Construct()
+	internal := emitter.Reserve("sys.*")
+
+	violations := []interface{}{}
+	emitter.On("schemaViolation", func(args ...interface{}) { violations = append(violations, args[1]) })
+
+	counter := 0
+	emitter.On("sys.shutdown", func(args ...interface{}) { counter++ })
+	expect(t, 0, emitter.ListenersCount("sys.shutdown"))
+	internal.On("sys.shutdown", func(args ...interface{}) { counter++ })
+
+	emitter.EmitSync("sys.shutdown")
+	expect(t, 0, counter)
+	internal.EmitSync("sys.shutdown")
+	expect(t, 1, counter)
+
+	// the meta-events can't be spoofed either
+	emitter.EmitSync("newListener", "spoofed", nil)
+	expect(t, ErrReserved, emitter.EmitValidated("sys.shutdown"))
+	expect(t, ErrReserved, emitter.CheckEmit("removeListener"))
+	expect(t, nil, emitter.CheckListen("removeListener"))
+	expect(t, nil, emitter.EmitValidated("user.created"))
+
+	expect(t, 3, len(violations))
+	for _, err := range violations {
+		expect(t, ErrReserved, err)
+	}
+}
@@ -0,0 +1,136 @@
+package Emitter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// PanicError - the panic of a listener, recovered by EmitAsyncResult
+type PanicError struct {
+	Listener SubscriptionID
+	Event    string
+	Value    interface{}
+}
+
+func (self *PanicError) Error() string {
+	return fmt.Sprintf("emitter: listener %d of %s panicked: %v", self.Listener, self.Event, self.Value)
+}
+
+// EmitResult - the outcome of the listeners of an EmitAsyncResult, complete once Done is closed
+type EmitResult struct {
+	done      chan struct{}
+	mutex     *sync.Mutex
+	pending   int
+	errors    []error
+	panics    int
+	durations map[SubscriptionID]time.Duration
+}
+
+// EmitAsyncResult() - run all listeners of the event in asynchronous mode, as EmitAsync does, the
+// result telling once they all returned, how long each one ran, their errors and the panics they raised,
+// recovered instead of crashing the process
+func (self *Emitter) EmitAsyncResult(event string, args ...interface{}) *EmitResult {
+	result := &EmitResult{
+		done:      make(chan struct{}),
+		mutex:     &sync.Mutex{},
+		durations: map[SubscriptionID]time.Duration{},
+	}
+
+	ev := &Event{Name: event, Args: args, failed: result.failed}
+	err := self.dispatchWith(ev, true, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			close(result.done)
+			return -1
+		}
+
+		listeners := []Listener{}
+		for _, v := range self.listenersOf(ev.Name) {
+			if !v.once || self.claimOnce(v) {
+				listeners = append(listeners, v)
+			}
+		}
+		if len(listeners) == 0 {
+			close(result.done)
+			return 0
+		}
+
+		result.pending = len(listeners)
+		limits := self.limitsOf(ev.Name)
+		clock := self.Clock()
+		for _, v := range listeners {
+			v := v
+			self.submitLimited(func() {
+				defer result.returned(v, ev.Name, clock, clock.Now())
+				v.callWait(ev)
+			}, limits, ev.Priority)
+		}
+		return len(listeners)
+	})
+	if err != nil {
+		close(result.done)
+	}
+	return result
+}
+
+// returned() - record the listener's run, recovering its panic, and complete the result after the last one
+func (self *EmitResult) returned(v Listener, event string, clock Clock, start time.Time) {
+	r := recover()
+	elapsed := clock.Now().Sub(start)
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.durations[v.ID()] = elapsed
+	if r != nil {
+		self.panics++
+		self.errors = append(self.errors, &PanicError{Listener: v.ID(), Event: event, Value: r})
+	}
+	if self.pending--; self.pending == 0 {
+		close(self.done)
+	}
+}
+
+// failed() - record the error of a listener
+func (self *EmitResult) failed(err error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.errors = append(self.errors, err)
+}
+
+// Done() - closed once all the listeners returned
+func (self *EmitResult) Done() <-chan struct{} {
+	return self.done
+}
+
+// Wait() - wait for all the listeners to return
+func (self *EmitResult) Wait() *EmitResult {
+	<-self.done
+	return self
+}
+
+// Errors() - the errors of the listeners, see OnContextErr, and their panics as *PanicError
+func (self *EmitResult) Errors() []error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return append([]error{}, self.errors...)
+}
+
+// PanicCount() - how many listeners panicked
+func (self *EmitResult) PanicCount() int {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.panics
+}
+
+// Durations() - how long each listener ran, by subscription
+func (self *EmitResult) Durations() map[SubscriptionID]time.Duration {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	durations := make(map[SubscriptionID]time.Duration, len(self.durations))
+	for id, d := range self.durations {
+		durations[id] = d
+	}
+	return durations
+}
@@ -0,0 +1,36 @@
+package Emitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmitAsyncResult(t *testing.T) {
+	pool := NewPool(2)
+	defer pool.Close()
+
+	for _, emitter := range []*Emitter{New(), New(WithPool(pool))} {
+		fast := emitter.Subscribe("user.created", func(args ...interface{}) {})
+		slow := emitter.Subscribe("user.created", func(args ...interface{}) { time.Sleep(20 * time.Millisecond) })
+		failing := emitter.Subscribe("user.created", func(args ...interface{}) { panic("boom") })
+
+		result := emitter.EmitAsyncResult("user.created", 1)
+		select {
+		case <-result.Done():
+		case <-time.After(time.Second):
+			t.Fatal("the result is never done")
+		}
+
+		durations := result.Durations()
+		expect(t, 3, len(durations))
+		expect(t, true, durations[slow.ID()] >= 20*time.Millisecond)
+		expect(t, true, durations[fast.ID()] < durations[slow.ID()])
+		expect(t, 1, result.PanicCount())
+		expect(t, 1, len(result.Errors()))
+		expect(t, "emitter: listener 3 of user.created panicked: boom", result.Errors()[0].Error())
+		expect(t, uint64(1), failing.Stats().Panics)
+
+		result = emitter.EmitAsyncResult("order.created").Wait()
+		expect(t, 0, len(result.Durations()), "an emit without listeners is done")
+	}
+}
@@ -0,0 +1,92 @@
+package Emitter
+
+import (
+	"math/rand"
+)
+
+// SamplingMode - how the sampled emits are picked, see SetSampling
+type SamplingMode int
+
+const (
+	// SampleRandom - each emit is delivered with the probability of the rate, the default
+	SampleRandom SamplingMode = iota
+	// SampleDeterministic - the emits are delivered evenly, i.e every 100th one at a 0.01 rate
+	SampleDeterministic
+)
+
+// sampler - the rate of the delivered emits of the events matching the pattern
+type sampler struct {
+	pattern string
+	rate    float64
+	credit  float64
+}
+
+// SetSampling() - deliver only the rate (0..1) of the emits of the events matching the pattern, the
+// others are dropped before reaching any listener, for extremely frequent events with expensive
+// listeners; a rate >= 1 removes the sampling. An emit matching several sampled patterns must be
+// picked by each of them
+func (self *Emitter) SetSampling(pattern string, rate float64) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	samplers := self.samplers[:0:0]
+	for _, s := range self.samplers {
+		if s.pattern != pattern {
+			samplers = append(samplers, s)
+		}
+	}
+	if rate < 1 {
+		samplers = append(samplers, &sampler{pattern: pattern, rate: rate})
+	}
+	self.samplers = samplers
+	return self
+}
+
+// SetSamplingMode() - pick the sampled emits randomly or evenly
+func (self *Emitter) SetSamplingMode(mode SamplingMode) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.samplingMode = mode
+	return self
+}
+
+// sampled() - whether the emit is picked by the samplers of its event
+func (self *Emitter) sampled(event string) bool {
+	if IsMetaEvent(event) {
+		return true
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if len(self.samplers) == 0 {
+		return true
+	}
+	event = self.key(event)
+	picked := true
+	for _, s := range self.samplers {
+		if self.compiled(s.pattern).match(event) {
+			picked = s.pick(self.samplingMode) && picked
+		}
+	}
+	return picked
+}
+
+// pick() - whether the sampler delivers the emit, the mutex must be held
+func (self *sampler) pick(mode SamplingMode) bool {
+	if self.rate <= 0 {
+		return false
+	}
+	if mode == SampleRandom {
+		return rand.Float64() < self.rate
+	}
+	// every emit earns the rate, one is delivered whenever a whole credit is earned
+	self.credit += self.rate
+	if self.credit >= 1 {
+		self.credit--
+		return true
+	}
+	return false
+}
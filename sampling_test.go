@@ -0,0 +1,37 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestSetSamplingDeterministic(t *testing.T) {
+	emitter := New().SetSamplingMode(SampleDeterministic).SetSampling("metrics.*", 0.01)
+	metrics, others := 0, 0
+	emitter.On("metrics.cpu", func(args ...interface{}) { metrics++ })
+	emitter.On("user.created", func(args ...interface{}) { others++ })
+
+	for i := 0; i < 1000; i++ {
+		emitter.EmitSync("metrics.cpu")
+		emitter.EmitSync("user.created")
+	}
+	expect(t, 10, metrics)
+	expect(t, 1000, others, "the other events aren't sampled")
+
+	emitter.SetSampling("metrics.*", 1)
+	emitter.EmitSync("metrics.cpu")
+	expect(t, 11, metrics, "the sampling is removed")
+
+	emitter.SetSampling("metrics.*", 0)
+	emitter.EmitSync("metrics.cpu")
+	expect(t, 11, metrics, "all dropped")
+}
+
+func TestSetSamplingRandom(t *testing.T) {
+	emitter := New().SetSampling("metrics.*", 0.5)
+	metrics := 0
+	emitter.On("metrics.cpu", func(args ...interface{}) { metrics++ })
+	for i := 0; i < 10000; i++ {
+		emitter.EmitSync("metrics.cpu")
+	}
+	expect(t, true, metrics > 4000 && metrics < 6000, "about half delivered")
+}
@@ -0,0 +1,186 @@
+package Emitter
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScheduledEmit - a pending delayed emit, as saved and restored
+type ScheduledEmit struct {
+	ID      uint64            `json:"id"`
+	At      time.Time         `json:"at"`
+	Name    string            `json:"name"`
+	Args    []interface{}     `json:"args"`
+	Headers map[string]string `json:"headers,omitempty"`
+}
+
+// ScheduleStore - where the pending delayed emits are persisted, saved whenever they change
+type ScheduleStore interface {
+	Save(pending []ScheduledEmit) error
+	Load() ([]ScheduledEmit, error)
+}
+
+// Scheduled - the handle of a delayed emit
+type Scheduled struct {
+	emitter *Emitter
+	id      uint64
+}
+
+// scheduler - the pending delayed emits of an emitter
+type scheduler struct {
+	pending map[uint64]*pendingEmit
+	seq     uint64
+	store   ScheduleStore
+	onError func(err error)
+	mutex   *sync.Mutex
+}
+
+type pendingEmit struct {
+	emit  ScheduledEmit
+	timer Timer
+}
+
+// EmitAfter() - emit the event in synchronous mode, in a goroutine of its own, once the delay elapsed
+func (self *Emitter) EmitAfter(delay time.Duration, event string, args ...interface{}) *Scheduled {
+	return self.EmitAt(self.Clock().Now().Add(delay), event, args...)
+}
+
+// EmitAt() - emit the event in synchronous mode, in a goroutine of its own, at the specified time
+func (self *Emitter) EmitAt(at time.Time, event string, args ...interface{}) *Scheduled {
+	s := self.scheduler()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.seq++
+	self.schedule(s, ScheduledEmit{ID: s.seq, At: at, Name: event, Args: args})
+	s.save()
+	return &Scheduled{emitter: self, id: s.seq}
+}
+
+// Cancel() - cancel the delayed emit, false if it already happened or was canceled
+func (self *Scheduled) Cancel() bool {
+	s := self.emitter.scheduler()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	p, ok := s.pending[self.id]
+	if !ok || !p.timer.Stop() {
+		return false
+	}
+	delete(s.pending, self.id)
+	s.save()
+	return true
+}
+
+// PendingEmits() - the pending delayed emits, the soonest first
+func (self *Emitter) PendingEmits() []ScheduledEmit {
+	s := self.scheduler()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.list()
+}
+
+// SaveSchedule() - write the pending delayed emits to w, as json, to restore them after a restart
+func (self *Emitter) SaveSchedule(w io.Writer) error {
+	return json.NewEncoder(w).Encode(self.PendingEmits())
+}
+
+// RestoreSchedule() - schedule the delayed emits saved by SaveSchedule, the ones past due are emitted
+// right away; the count of restored emits is returned
+func (self *Emitter) RestoreSchedule(r io.Reader) (int, error) {
+	pending := []ScheduledEmit{}
+	if err := json.NewDecoder(r).Decode(&pending); err != nil {
+		return 0, err
+	}
+	self.restore(pending)
+	return len(pending), nil
+}
+
+// PersistSchedule() - restore the delayed emits of the store, then save the pending ones to it
+// whenever they change, so they survive the process restarts; onError, if set, is called with
+// the errors of saving them. The count of restored emits is returned
+func (self *Emitter) PersistSchedule(store ScheduleStore, onError func(err error)) (int, error) {
+	pending, err := store.Load()
+	if err != nil {
+		return 0, err
+	}
+	self.restore(pending)
+
+	s := self.scheduler()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.store, s.onError = store, onError
+	s.save()
+	return len(pending), nil
+}
+
+// restore() - schedule the emits, with new ids
+func (self *Emitter) restore(pending []ScheduledEmit) {
+	s := self.scheduler()
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for _, emit := range pending {
+		s.seq++
+		emit.ID = s.seq
+		self.schedule(s, emit)
+	}
+	s.save()
+}
+
+// schedule() - start the timer of the emit, the scheduler's mutex must be held
+func (self *Emitter) schedule(s *scheduler, emit ScheduledEmit) {
+	p := &pendingEmit{emit: emit}
+	s.pending[emit.ID] = p
+	p.timer = self.Clock().AfterFunc(emit.At.Sub(self.Clock().Now()), func() {
+		s.mutex.Lock()
+		_, ok := s.pending[emit.ID]
+		delete(s.pending, emit.ID)
+		s.save()
+		s.mutex.Unlock()
+
+		if ok {
+			self.EmitEvent(&Event{Name: emit.Name, Args: emit.Args, Headers: emit.Headers})
+		}
+	})
+}
+
+// scheduler() - the emitter's scheduler, created on first use
+func (self *Emitter) scheduler() *scheduler {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.schedules == nil {
+		self.schedules = &scheduler{pending: make(map[uint64]*pendingEmit), mutex: &sync.Mutex{}}
+	}
+	return self.schedules
+}
+
+// list() - the pending emits, the soonest first, the mutex must be held
+func (self *scheduler) list() []ScheduledEmit {
+	list := make([]ScheduledEmit, 0, len(self.pending))
+	for _, p := range self.pending {
+		list = append(list, p.emit)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if !list[i].At.Equal(list[j].At) {
+			return list[i].At.Before(list[j].At)
+		}
+		return list[i].ID < list[j].ID
+	})
+	return list
+}
+
+// save() - save the pending emits to the store, if any, the mutex must be held
+func (self *scheduler) save() {
+	if self.store == nil {
+		return
+	}
+	if err := self.store.Save(self.list()); err != nil && self.onError != nil {
+		self.onError(err)
+	}
+}
@@ -0,0 +1,71 @@
+package Emitter
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+	"time"
+)
+
+// memoryStore - a ScheduleStore keeping the last saved emits
+type memoryStore struct {
+	saved []ScheduledEmit
+	mutex sync.Mutex
+}
+
+func (self *memoryStore) Save(pending []ScheduledEmit) error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	self.saved = pending
+	return nil
+}
+
+func (self *memoryStore) Load() ([]ScheduledEmit, error) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.saved, nil
+}
+
+func TestEmitAfter(t *testing.T) {
+	emitter := Construct()
+
+	fired := make(chan interface{}, 2)
+	emitter.On("reminder", func(args ...interface{}) { fired <- args[0] })
+
+	emitter.EmitAfter(time.Millisecond, "reminder", "soon")
+	canceled := emitter.EmitAfter(time.Hour, "reminder", "later")
+	expect(t, "soon", <-fired)
+	expect(t, 1, len(emitter.PendingEmits()))
+	expect(t, true, canceled.Cancel())
+	expect(t, false, canceled.Cancel())
+	expect(t, 0, len(emitter.PendingEmits()))
+}
+
+func TestScheduleSurvivesRestart(t *testing.T) {
+	store := &memoryStore{}
+	before := Construct()
+	before.PersistSchedule(store, nil)
+	before.EmitAfter(time.Hour, "later", "a")
+	expect(t, "later", store.saved[0].Name)
+
+	buf := &bytes.Buffer{}
+	before.SaveSchedule(buf)
+
+	// the process was down when this one was due
+	store.saved = append(store.saved, ScheduledEmit{At: time.Now().Add(-time.Second), Name: "missed", Args: []interface{}{"b"}})
+
+	after := Construct()
+	fired := make(chan interface{}, 1)
+	after.On("missed", func(args ...interface{}) { fired <- args[0] })
+	count, err := after.PersistSchedule(store, nil)
+	expect(t, nil, err)
+	expect(t, 2, count)
+	expect(t, "b", <-fired, "emitted right away")
+	expect(t, 1, len(after.PendingEmits()))
+	expect(t, "later", after.PendingEmits()[0].Name)
+
+	restored := Construct()
+	count, _ = restored.RestoreSchedule(buf)
+	expect(t, 1, count)
+	expect(t, "a", restored.PendingEmits()[0].Args[0])
+}
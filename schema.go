@@ -0,0 +1,174 @@
+package Emitter
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUnknownEvent - the event isn't declared in the schema registry
+var ErrUnknownEvent = errors.New("emitter: event not declared")
+
+// SchemaMode - what happens to the emits not following their schema
+type SchemaMode int
+
+const (
+	// SchemaReport - deliver the emit and raise a "schemaViolation" meta-event, the default
+	SchemaReport SchemaMode = iota
+	// SchemaReject - drop the emit and raise a "schemaViolation" meta-event
+	SchemaReject
+)
+
+// Schema - the declared shape of an event's args
+type Schema struct {
+	// Args - the expected type of each arg, a nil type accepts anything
+	Args []reflect.Type
+	// Variadic - args beyond the declared ones are accepted instead of being a mismatch
+	Variadic bool
+	// Check - an optional custom validation of the args, run after the types are checked
+	Check func(args []interface{}) error
+	// Version - the version of the args the producers emit, see RegisterMigration
+	Version int
+}
+
+// ArgTypes() - the types of the sample values, to build a Schema's Args, i.e ArgTypes("", 0)
+func ArgTypes(samples ...interface{}) []reflect.Type {
+	types := make([]reflect.Type, len(samples))
+	for i, sample := range samples {
+		types[i] = reflect.TypeOf(sample)
+	}
+	return types
+}
+
+// Validate() - check the args against the schema
+func (self Schema) Validate(args []interface{}) error {
+	if len(args) < len(self.Args) || (!self.Variadic && len(args) > len(self.Args)) {
+		return fmt.Errorf("emitter: expected %d args, got %d", len(self.Args), len(args))
+	}
+	for i, typ := range self.Args {
+		if typ == nil {
+			continue
+		}
+		if args[i] == nil || !reflect.TypeOf(args[i]).AssignableTo(typ) {
+			return fmt.Errorf("emitter: arg %d expected to be %v, got %T", i, typ, args[i])
+		}
+	}
+	if self.Check != nil {
+		return self.Check(args)
+	}
+	return nil
+}
+
+// RegisterEvent() - declare the event and the schema its args must follow, once any event
+// is declared listening on an undeclared event name is reported as a violation too
+func (self *Emitter) RegisterEvent(event string, schema Schema) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.schemas == nil {
+		self.schemas = make(map[string]Schema)
+	}
+	self.schemas[event] = schema
+	return self
+}
+
+// SetSchemaMode() - choose what happens to the emits not following their schema
+func (self *Emitter) SetSchemaMode(mode SchemaMode) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.schemaMode = mode
+	return self
+}
+
+// Schema() - return the schema of the declared event
+func (self *Emitter) Schema(event string) (Schema, bool) {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	schema, ok := self.schemas[event]
+	return schema, ok
+}
+
+// ValidateArgs() - check the args against the schema of the event and the validators of the patterns
+// it matches, nil if nothing is declared
+func (self *Emitter) ValidateArgs(event string, args []interface{}) error {
+	if err := self.schemaError(event, args); err != nil {
+		return err
+	}
+	return self.validatorError(event, args)
+}
+
+// schemaError() - check the args against the schema of the event
+func (self *Emitter) schemaError(event string, args []interface{}) error {
+	self.mutex.Lock()
+	schema, ok := self.schemas[event]
+	declared := len(self.schemas) > 0
+	self.mutex.Unlock()
+
+	if !ok {
+		if declared && !IsMetaEvent(event) {
+			return ErrUnknownEvent
+		}
+		return nil
+	}
+	if err := schema.Validate(args); err != nil {
+		return fmt.Errorf("%s: %v", event, err)
+	}
+	return nil
+}
+
+// EmitValidated() - validate the args and run the listeners in synchronous mode, an invalid
+// emit isn't delivered and its error returned instead of raising a "schemaViolation"
+func (self *Emitter) EmitValidated(event string, args ...interface{}) error {
+	if err := self.CheckEmit(event); err != nil {
+		return err
+	}
+	if err := self.ValidateArgs(event, args); err != nil {
+		return err
+	}
+	self.EmitSync(event, args...)
+	return nil
+}
+
+// validateEmit() - whether the event can be delivered, reporting its violation if any
+func (self *Emitter) validateEmit(ev *Event) bool {
+	if IsMetaEvent(ev.Name) {
+		return true
+	}
+
+	if err := self.schemaError(ev.Name, ev.Args); err != nil {
+		self.mutex.Lock()
+		mode := self.schemaMode
+		self.mutex.Unlock()
+
+		self.emitMeta("schemaViolation", ev.Name, err)
+		if mode == SchemaReject {
+			return false
+		}
+	}
+
+	if err := self.validatorError(ev.Name, ev.Args); err != nil {
+		self.emitMeta("schemaViolation", ev.Name, err)
+		self.deadLetter(ev, err)
+		return false
+	}
+	return true
+}
+
+// validateListener() - report the listeners registered on undeclared events
+func (self *Emitter) validateListener(event string) {
+	if IsMetaEvent(event) || strings.Contains(event, "*") {
+		return
+	}
+
+	self.mutex.Lock()
+	_, ok := self.schemas[event]
+	declared := len(self.schemas) > 0
+	self.mutex.Unlock()
+
+	if declared && !ok {
+		self.emitMeta("schemaViolation", event, ErrUnknownEvent)
+	}
+}
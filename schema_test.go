@@ -0,0 +1,66 @@
+package Emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+type userCreated struct {
+	Name string
+}
+
+func TestSchemaReport(t *testing.T) {
+	emitter := Construct()
+	emitter.RegisterEvent("user.created", Schema{Args: ArgTypes(userCreated{})})
+
+	violations := []string{}
+	emitter.On("schemaViolation", func(args ...interface{}) {
+		violations = append(violations, args[0].(string))
+	})
+
+	counter := 0
+	emitter.On("user.created", func(args ...interface{}) {
+		counter++
+	})
+	emitter.On("user.removed", func(args ...interface{}) {})
+
+	emitter.EmitSync("user.created", userCreated{"john"})
+	emitter.EmitSync("user.created", "john")
+
+	expect(t, 2, counter)
+	expect(t, 2, len(violations))
+	expect(t, "user.removed", violations[0])
+	expect(t, "user.created", violations[1])
+}
+
+func TestSchemaReject(t *testing.T) {
+	emitter := Construct().SetSchemaMode(SchemaReject)
+	emitter.RegisterEvent("user.created", Schema{
+		Args:     ArgTypes(""),
+		Variadic: true,
+		Check: func(args []interface{}) error {
+			if args[0] == "" {
+				return errors.New("empty name")
+			}
+			return nil
+		},
+	})
+
+	counter := 0
+	emitter.On("user.*", func(args ...interface{}) {
+		counter++
+	})
+
+	emitter.EmitSync("user.created", "john", 42)
+	emitter.EmitSync("user.created", 42)
+	emitter.EmitSync("user.created", "")
+	emitter.EmitSync("user.unknown")
+
+	expect(t, 1, counter)
+	expect(t, ErrUnknownEvent, emitter.EmitValidated("user.unknown"))
+	if err := emitter.EmitValidated("user.created"); err == nil {
+		t.Errorf("expected an error for the missing args")
+	}
+	expect(t, nil, emitter.EmitValidated("user.created", "jane"))
+	expect(t, 2, counter)
+}
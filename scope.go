@@ -0,0 +1,41 @@
+package Emitter
+
+// Bridge() - mark the subscribed listener as the one of a transport bridge forwarding the events
+// to other processes: EmitLocal doesn't run it while EmitRemote runs only such listeners
+func (self *Subscription) Bridge() *Subscription {
+	return self.update(func(l *Listener) { l.bridge = true })
+}
+
+// Bridged() - whether the listener is a transport bridge's
+func (self Listener) Bridged() bool {
+	return self.bridge
+}
+
+// EmitLocal() - run the listeners of the event in synchronous mode, except the bridges' ones,
+// so the event doesn't leave the process, i.e to re-emit the events received from a bridge
+func (self *Emitter) EmitLocal(event string, args ...interface{}) *Emitter {
+	return self.emitScoped(event, args, false)
+}
+
+// EmitRemote() - run only the bridges' listeners of the event in synchronous mode,
+// so the event is forwarded to the other processes without running locally
+func (self *Emitter) EmitRemote(event string, args ...interface{}) *Emitter {
+	return self.emitScoped(event, args, true)
+}
+
+// emitScoped() - run the listeners of the event that are, or aren't, the bridges' ones
+func (self *Emitter) emitScoped(event string, args []interface{}, bridged bool) *Emitter {
+	self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		scoped := []Listener{}
+		for _, l := range self.listenersOf(ev.Name) {
+			if l.bridge == bridged {
+				scoped = append(scoped, l)
+			}
+		}
+		return self.run(ev, scoped, false)
+	})
+	return self
+}
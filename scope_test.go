@@ -0,0 +1,25 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestEmitScopes(t *testing.T) {
+	emitter := Construct()
+
+	local, remote := 0, 0
+	emitter.On("user.created", func(args ...interface{}) { local++ })
+	emitter.OnEvent("user.*", func(ev *Event) { remote++ }).Bridge()
+
+	emitter.EmitLocal("user.created")
+	expect(t, 1, local)
+	expect(t, 0, remote)
+
+	emitter.EmitRemote("user.created")
+	expect(t, 1, local)
+	expect(t, 1, remote)
+
+	emitter.EmitSync("user.created")
+	expect(t, 2, local)
+	expect(t, 2, remote)
+}
@@ -0,0 +1,93 @@
+package Emitter
+
+// EmitOption - a setting of a single EmitWith, i.e narrowing down the listeners it runs
+type EmitOption func(*emitOptions)
+
+// emitOptions - the settings of an EmitWith
+type emitOptions struct {
+	emitter  *Emitter
+	async    bool
+	filters  []func([]Listener) []Listener
+	chunk    int
+	progress func(done, total int)
+}
+
+// OnlyGroup() - run only the listeners of the group, see Subscription.Group
+func OnlyGroup(group string) EmitOption {
+	return selecting(func(l Listener) bool { return l.group == group })
+}
+
+// ExcludePattern() - don't run the listeners registered on an event (pattern) matching the pattern,
+// in the emitter's dialect, see WithMatcher
+func ExcludePattern(pattern string) EmitOption {
+	return func(o *emitOptions) {
+		o.emitter.mutex.Lock()
+		compiled := o.emitter.compiled(o.emitter.key(pattern))
+		o.emitter.mutex.Unlock()
+
+		selecting(func(l Listener) bool { return !compiled.match(l.event) })(o)
+	}
+}
+
+// MaxListeners() - run the first n listeners only, in their registration order
+func MaxListeners(n int) EmitOption {
+	return func(o *emitOptions) {
+		o.filters = append(o.filters, func(listeners []Listener) []Listener {
+			if n < len(listeners) {
+				return listeners[:n]
+			}
+			return listeners
+		})
+	}
+}
+
+// Async() - run the listeners in asynchronous mode, as EmitAsync does
+func Async() EmitOption {
+	return func(o *emitOptions) {
+		o.async = true
+	}
+}
+
+// EmitWith() - run the listeners of the event selected by the options, in synchronous mode
+// unless Async is given, the options applying in their order
+func (self *Emitter) EmitWith(event string, args []interface{}, opts ...EmitOption) *Emitter {
+	options := &emitOptions{emitter: self}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	self.dispatchWith(&Event{Name: event, Args: args}, options.async, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		listeners := self.listenersOf(ev.Name)
+		if len(options.filters) > 0 {
+			// the frozen emitters share their resolved listeners, they must not be modified
+			listeners = append([]Listener{}, listeners...)
+		}
+		for _, filter := range options.filters {
+			listeners = filter(listeners)
+		}
+		if options.chunk > 0 {
+			self.runChunked(ev, listeners, options)
+			return len(listeners)
+		}
+		return self.run(ev, listeners, async)
+	})
+	return self
+}
+
+// selecting() - an option keeping the listeners accepted by the predicate
+func selecting(keep func(Listener) bool) EmitOption {
+	return func(o *emitOptions) {
+		o.filters = append(o.filters, func(listeners []Listener) []Listener {
+			selected := listeners[:0]
+			for _, l := range listeners {
+				if keep(l) {
+					selected = append(selected, l)
+				}
+			}
+			return selected
+		})
+	}
+}
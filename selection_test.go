@@ -0,0 +1,48 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEmitWith(t *testing.T) {
+	emitter := Construct()
+
+	calls := []string{}
+	listener := func(name string) func(...interface{}) {
+		return func(args ...interface{}) { calls = append(calls, name) }
+	}
+	emitter.OnEvents([]string{"user.created"}, listener("audit"))[0].Group("audit")
+	emitter.OnEvents([]string{"debug.*"}, listener("debug"))
+	emitter.OnEvents([]string{"**"}, listener("all"))
+	emitter.OnEvents([]string{"user.*"}, listener("users"))
+	emitter.Freeze()
+	calls = calls[:0]
+
+	emitter.EmitWith("user.created", nil, OnlyGroup("audit"))
+	expect(t, "audit", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	emitter.EmitWith("user.created", nil, ExcludePattern("user.*"), MaxListeners(1))
+	expect(t, "all", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	emitter.EmitWith("debug.trace", nil, ExcludePattern("debug.*"))
+	expect(t, "all", strings.Join(calls, ","))
+
+	calls = calls[:0]
+	emitter.EmitSync("user.created")
+	expect(t, "audit,all,users", strings.Join(calls, ","), "the options don't outlive their emit")
+}
+
+func TestExcludePatternDialect(t *testing.T) {
+	emitter := New(WithMatcher(AMQP))
+
+	calls := []string{}
+	emitter.On("order.#", func(args ...interface{}) { calls = append(calls, "orders") })
+	emitter.On("order.created", func(args ...interface{}) { calls = append(calls, "created") })
+	emitter.On("user.created", func(args ...interface{}) { calls = append(calls, "users") })
+
+	emitter.EmitWith("order.created", nil, ExcludePattern("order.#"))
+	expect(t, "", strings.Join(calls, ","), "matched with the emitter's dialect")
+}
@@ -0,0 +1,46 @@
+package Emitter
+
+import (
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// SignalName() - the name of the signal as used in the events, i.e "SIGTERM"
+func SignalName(sig os.Signal) string {
+	if name, ok := signalNames[sig]; ok {
+		return name
+	}
+	return strings.ToUpper(strings.Replace(sig.String(), " ", "_", -1))
+}
+
+// relaySignals() - emit the signals notified as events until the returned stop function is called, see BindSignals
+func relaySignals(e *Emitter, prefix string, sigs []os.Signal) (stop func()) {
+	if prefix != "" && !strings.HasSuffix(prefix, ".") {
+		prefix += "."
+	}
+
+	ch := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(ch, sigs...)
+
+	go func() {
+		for {
+			select {
+			case sig := <-ch:
+				e.EmitSync(prefix+SignalName(sig), sig)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stopped := false
+	return func() {
+		if !stopped {
+			stopped = true
+			signal.Stop(ch)
+			close(done)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+//go:build unix || windows
+// +build unix windows
+
+package Emitter
+
+import (
+	"os"
+	"syscall"
+)
+
+// signalNames - the conventional names of the portable signals, others are named after their description
+var signalNames = map[os.Signal]string{
+	syscall.SIGABRT: "SIGABRT",
+	syscall.SIGALRM: "SIGALRM",
+	syscall.SIGBUS:  "SIGBUS",
+	syscall.SIGFPE:  "SIGFPE",
+	syscall.SIGHUP:  "SIGHUP",
+	syscall.SIGILL:  "SIGILL",
+	syscall.SIGINT:  "SIGINT",
+	syscall.SIGKILL: "SIGKILL",
+	syscall.SIGPIPE: "SIGPIPE",
+	syscall.SIGQUIT: "SIGQUIT",
+	syscall.SIGSEGV: "SIGSEGV",
+	syscall.SIGTERM: "SIGTERM",
+	syscall.SIGTRAP: "SIGTRAP",
+}
+
+// BindSignals() - emit the received OS signals as "<prefix>.<signal name>" events (i.e "sys.signal.SIGTERM")
+// with the signal as their only arg, until the returned stop function is called;
+// without sigs all the incoming signals are relayed, as with signal.Notify
+func BindSignals(e *Emitter, prefix string, sigs ...os.Signal) (stop func()) {
+	return relaySignals(e, prefix, sigs)
+}
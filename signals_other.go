@@ -0,0 +1,24 @@
+//go:build !unix && !windows
+// +build !unix,!windows
+
+package Emitter
+
+import (
+	"os"
+)
+
+// signalNames - the only signals known on every platform
+var signalNames = map[os.Signal]string{
+	os.Interrupt: "SIGINT",
+	os.Kill:      "SIGKILL",
+}
+
+// BindSignals() - emit the received OS signals as "<prefix>.<signal name>" events (i.e "sys.signal.SIGINT")
+// with the signal as their only arg, until the returned stop function is called; only os.Interrupt
+// and os.Kill are portable to this platform, they are relayed without sigs
+func BindSignals(e *Emitter, prefix string, sigs ...os.Signal) (stop func()) {
+	if len(sigs) == 0 {
+		sigs = []os.Signal{os.Interrupt, os.Kill}
+	}
+	return relaySignals(e, prefix, sigs)
+}
@@ -0,0 +1,22 @@
+//go:build unix
+// +build unix
+
+package Emitter
+
+import (
+	"syscall"
+)
+
+func init() {
+	for sig, name := range map[syscall.Signal]string{
+		syscall.SIGCHLD:  "SIGCHLD",
+		syscall.SIGCONT:  "SIGCONT",
+		syscall.SIGSTOP:  "SIGSTOP",
+		syscall.SIGTSTP:  "SIGTSTP",
+		syscall.SIGUSR1:  "SIGUSR1",
+		syscall.SIGUSR2:  "SIGUSR2",
+		syscall.SIGWINCH: "SIGWINCH",
+	} {
+		signalNames[sig] = name
+	}
+}
@@ -0,0 +1,34 @@
+//go:build unix
+// +build unix
+
+package Emitter
+
+import (
+	"os"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestBindSignals(t *testing.T) {
+	emitter := Construct()
+
+	received := make(chan os.Signal, 1)
+	emitter.On("sys.signal.SIGHUP", func(args ...interface{}) {
+		received <- args[0].(os.Signal)
+	})
+
+	stop := BindSignals(emitter, "sys.signal", syscall.SIGHUP)
+	defer stop()
+
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+
+	select {
+	case sig := <-received:
+		expect(t, syscall.SIGHUP, sig)
+	case <-time.After(time.Second):
+		t.Fatal("signal not emitted")
+	}
+
+	expect(t, "SIGUSR1", SignalName(syscall.SIGUSR1))
+}
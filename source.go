@@ -0,0 +1,51 @@
+package Emitter
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// HeaderSource - the header holding where an event was emitted from, "file:line function"
+const HeaderSource = "source"
+
+// WithSourceAttribution() - stamp the emits with the HeaderSource header telling their caller, so
+// it shows in the envelopes, the journals and the recordings. The depth is the caller's rank past
+// the emitter's methods, 1 for the direct caller, more to skip the caller's own emit helpers. It
+// walks the stack on each emit, the emits already stamped (i.e by another process) are kept as is
+func WithSourceAttribution(depth int) Option {
+	return func(e *Emitter) {
+		if depth < 1 {
+			depth = 1
+		}
+		e.sourceDepth = depth
+	}
+}
+
+// attribute() - stamp the event with its caller if attributing the sources
+func (self *Emitter) attribute(ev *Event) {
+	if self.sourceDepth == 0 || IsMetaEvent(ev.Name) || ev.Headers[HeaderSource] != "" {
+		return
+	}
+	headers := make(map[string]string, len(ev.Headers)+1)
+	for k, v := range ev.Headers {
+		headers[k] = v
+	}
+	headers[HeaderSource] = self.caller(self.sourceDepth)
+	ev.Headers = headers
+}
+
+// caller() - the depth-th caller outside of the emitter's methods
+func (self *Emitter) caller(depth int) string {
+	pcs := make([]uintptr, 32+depth)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !strings.Contains(frame.Function, "goemitter.(*") {
+			depth--
+		}
+		if depth == 0 || !more {
+			return fmt.Sprintf("%s:%d %s", frame.File, frame.Line, frame.Function)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func emitFrom(e *Emitter) {
+	e.EmitSync("user.created")
+}
+
+func TestWithSourceAttribution(t *testing.T) {
+	var source string
+	emitter := New(WithSourceAttribution(1))
+	emitter.OnEvent("user.created", func(ev *Event) { source = ev.Headers[HeaderSource] })
+
+	emitFrom(emitter)
+	expect(t, true, strings.Contains(source, "source_test.go:"), "the file of the emit")
+	expect(t, true, strings.HasSuffix(source, "goemitter.emitFrom"), "the function of the emit")
+
+	deeper := New(WithSourceAttribution(2))
+	deeper.OnEvent("user.created", func(ev *Event) { source = ev.Headers[HeaderSource] })
+	emitFrom(deeper)
+	expect(t, true, strings.HasSuffix(source, "goemitter.TestWithSourceAttribution"), "the caller of the helper")
+
+	emitter.EmitEvent(&Event{Name: "user.created", Headers: map[string]string{HeaderSource: "node-2"}})
+	expect(t, "node-2", source, "an existing source is kept")
+
+	recorder := emitter.Record()
+	emitFrom(emitter)
+	expect(t, true, strings.HasSuffix(recorder.Stop().Emits[0].Headers[HeaderSource], "goemitter.emitFrom"), "recorded")
+
+	plain := New()
+	plain.OnEvent("user.created", func(ev *Event) { source = ev.Headers[HeaderSource] })
+	emitFrom(plain)
+	expect(t, "", source, "not attributed by default")
+}
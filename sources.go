@@ -0,0 +1,51 @@
+package Emitter
+
+import (
+	"context"
+	"time"
+)
+
+// EmitFromChannel() - emit every value received from the channel as the only arg of the event,
+// in its own goroutine; the returned channel is closed once ch is closed and drained
+func (self *Emitter) EmitFromChannel(event string, ch <-chan interface{}) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for v := range ch {
+			self.EmitSync(event, v)
+		}
+	}()
+	return done
+}
+
+// EmitOnDone() - emit the event once, in its own goroutine, when the context is done
+func (self *Emitter) EmitOnDone(ctx context.Context, event string, args ...interface{}) {
+	go func() {
+		<-ctx.Done()
+		self.EmitSync(event, args...)
+	}()
+}
+
+// EmitOnTicker() - emit the event with the tick time as its only arg on every tick of the ticker,
+// until the returned stop function is called, which doesn't stop the ticker itself
+func (self *Emitter) EmitOnTicker(ticker *time.Ticker, event string) (stop func()) {
+	done := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case tick := <-ticker.C:
+				self.EmitSync(event, tick)
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	stopped := false
+	return func() {
+		if !stopped {
+			stopped = true
+			close(done)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package Emitter
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestEmitFromChannel(t *testing.T) {
+	emitter := Construct()
+
+	values := []interface{}{}
+	emitter.On("values", func(args ...interface{}) {
+		values = append(values, args[0])
+	})
+
+	ch := make(chan interface{})
+	done := emitter.EmitFromChannel("values", ch)
+	ch <- 1
+	ch <- "two"
+	close(ch)
+	<-done
+
+	expect(t, 2, len(values))
+	expect(t, 1, values[0])
+	expect(t, "two", values[1])
+}
+
+func TestEmitOnDone(t *testing.T) {
+	emitter := Construct()
+
+	received := make(chan interface{}, 1)
+	emitter.On("shutdown", func(args ...interface{}) {
+		received <- args[0]
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	emitter.EmitOnDone(ctx, "shutdown", "bye")
+	cancel()
+
+	select {
+	case arg := <-received:
+		expect(t, "bye", arg)
+	case <-time.After(time.Second):
+		t.Fatal("context cancellation not emitted")
+	}
+}
+
+func TestEmitOnTicker(t *testing.T) {
+	emitter := Construct()
+
+	ticks := make(chan time.Time, 10)
+	emitter.On("tick", func(args ...interface{}) {
+		ticks <- args[0].(time.Time)
+	})
+
+	ticker := time.NewTicker(time.Millisecond)
+	defer ticker.Stop()
+	stop := emitter.EmitOnTicker(ticker, "tick")
+
+	for i := 0; i < 3; i++ {
+		select {
+		case <-ticks:
+		case <-time.After(time.Second):
+			t.Fatal("tick not emitted")
+		}
+	}
+	stop()
+	stop()
+}
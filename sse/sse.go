@@ -0,0 +1,166 @@
+// Package sse exposes the events of an emitter over Server-Sent Events.
+// Every matching event is written to the connected clients as
+//
+//	event: <event name>
+//	data: <json encoded args>
+//
+// clients may narrow the stream down with one or more `?filter=<pattern>` query params.
+//
+// The emitter's own "newListener"/"removeListener" meta-events are never streamed,
+// neither are events whose name contains a line break, as it can't be framed.
+package sse
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Stream - an http.Handler streaming the emitter's events to its clients
+type Stream struct {
+	// Filter - decide whether the event is sent to the client of the specified request,
+	// defaults to matching the event against the request's "filter" query params
+	Filter func(r *http.Request, ev *Emitter.Event) bool
+	// Buffer - the count of events queued per client before the slow client starts losing events
+	Buffer int
+
+	subscription *Emitter.Subscription
+	clients      map[*client]bool
+	closed       bool
+	mutex        *sync.Mutex
+}
+
+type client struct {
+	request *http.Request
+	events  chan []byte
+}
+
+// Handler() - create a new Stream of the events matching the specified pattern
+func Handler(e *Emitter.Emitter, pattern string) *Stream {
+	stream := &Stream{
+		Filter:  FilterByQuery,
+		Buffer:  64,
+		clients: make(map[*client]bool),
+		mutex:   &sync.Mutex{},
+	}
+	stream.subscription = e.OnEvent(pattern, stream.broadcast).Bridge()
+	return stream
+}
+
+// FilterByQuery() - match the event against the "filter" query params of the request (all if none)
+func FilterByQuery(r *http.Request, ev *Emitter.Event) bool {
+	filters := r.URL.Query()["filter"]
+	if len(filters) == 0 {
+		return true
+	}
+	for _, pattern := range filters {
+		if Emitter.Match(pattern, ev.Name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Close() - stop listening on the emitter, connected clients are disconnected and new ones rejected
+func (self *Stream) Close() {
+	self.subscription.Remove()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.closed = true
+	for c := range self.clients {
+		close(c.events)
+		delete(self.clients, c)
+	}
+}
+
+// ServeHTTP() - stream the events to the client until it disconnects
+func (self *Stream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	c := &client{r, make(chan []byte, self.Buffer)}
+	self.mutex.Lock()
+	if self.closed {
+		self.mutex.Unlock()
+		http.Error(w, "stream closed", http.StatusServiceUnavailable)
+		return
+	}
+	self.clients[c] = true
+	self.mutex.Unlock()
+
+	defer func() {
+		self.mutex.Lock()
+		delete(self.clients, c)
+		self.mutex.Unlock()
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case msg, ok := <-c.events:
+			if !ok {
+				return
+			}
+			if _, err := w.Write(msg); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// broadcast() - queue the event on every client whose filter accepts it
+func (self *Stream) broadcast(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) || strings.ContainsAny(ev.Name, "\r\n") {
+		return
+	}
+
+	var msg []byte
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for c := range self.clients {
+		if !self.Filter(c.request, ev) {
+			continue
+		}
+		if msg == nil {
+			msg = encode(ev)
+		}
+		select {
+		case c.events <- msg:
+		default:
+			// slow client, drop the event instead of blocking the emitter
+		}
+	}
+}
+
+// encode() - format the event as a server-sent event
+func encode(ev *Emitter.Event) []byte {
+	args := ev.Args
+	if args == nil {
+		args = []interface{}{}
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		data, _ = json.Marshal(err.Error())
+		return []byte(fmt.Sprintf("event: error\ndata: %s\n\n", data))
+	}
+	return []byte(fmt.Sprintf("event: %s\ndata: %s\n\n", ev.Name, data))
+}
@@ -0,0 +1,101 @@
+package sse
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+func TestStreamFilters(t *testing.T) {
+	emitter := Emitter.Construct()
+	stream := Handler(emitter, "user.*")
+	defer stream.Close()
+
+	frames := connect(t, stream, "?filter=user.created")
+
+	emitter.EmitSync("user.deleted", "john")
+	emitter.EmitSync("account.created", "john")
+	emitter.EmitSync("user.created", "john", 42)
+
+	expect(t, "event: user.created", frames.next(t))
+	expect(t, `data: ["john",42]`, frames.next(t))
+}
+
+func TestStreamSkipsMetaEvents(t *testing.T) {
+	emitter := Emitter.Construct()
+	stream := Handler(emitter, "**")
+	defer stream.Close()
+
+	frames := connect(t, stream, "")
+
+	emitter.On("user.created", func(args ...interface{}) {})
+	emitter.EmitSync("user\ncreated", "john")
+	emitter.EmitSync("user.created", "john")
+
+	expect(t, "event: user.created", frames.next(t))
+	expect(t, `data: ["john"]`, frames.next(t))
+}
+
+func TestStreamClose(t *testing.T) {
+	emitter := Emitter.Construct()
+	first := Handler(emitter, "user.*")
+	second := Handler(emitter, "user.*")
+	defer second.Close()
+
+	first.Close()
+
+	expect(t, 1, emitter.ListenersCount("user.created"))
+	emitter.EmitSync("user.created", "john")
+}
+
+// frames - the non empty lines read from an event stream
+type frames struct {
+	reader *bufio.Reader
+}
+
+func (self frames) next(t *testing.T) string {
+	for {
+		line, err := self.reader.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+}
+
+// connect() - connect a client to the stream and wait for it to be registered
+func connect(t *testing.T, stream *Stream, query string) frames {
+	server := httptest.NewServer(stream)
+	t.Cleanup(server.Close)
+
+	res, err := http.Get(server.URL + query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+
+	for i := 0; i < 100; i++ {
+		stream.mutex.Lock()
+		n := len(stream.clients)
+		stream.mutex.Unlock()
+		if n == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	return frames{bufio.NewReader(res.Body)}
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
@@ -0,0 +1,95 @@
+package Emitter
+
+import (
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// ListenerStats - the invocations of a registration, to spot the dead listeners never run and the hot ones
+type ListenerStats struct {
+	ID SubscriptionID
+	// Event - the event (pattern) the listener is registered on, the first one for OnPatterns
+	Event string
+	Calls uint64
+	// Panics - the invocations which panicked
+	Panics uint64
+	// LastCalled - when the listener last started running, zero if it never did
+	LastCalled time.Time
+}
+
+// invocations - the counters of a registration, shared by the copies of its listener
+type invocations struct {
+	calls   uint64
+	panics  uint64
+	last    int64
+	emitter *Emitter
+}
+
+// record() - count an invocation starting now
+func (self *invocations) record() {
+	atomic.AddUint64(&self.calls, 1)
+	atomic.StoreInt64(&self.last, self.emitter.Clock().Now().UnixNano())
+}
+
+// returned() - count the panic of the invocation, unless it returned
+func (self *invocations) returned(returned *bool) {
+	if !*returned {
+		atomic.AddUint64(&self.panics, 1)
+	}
+}
+
+// Stats() - the invocations of the listener
+func (self Listener) Stats() ListenerStats {
+	stats := ListenerStats{ID: self.ID(), Event: self.event}
+	if self.stats == nil {
+		return stats
+	}
+	stats.Calls = atomic.LoadUint64(&self.stats.calls)
+	stats.Panics = atomic.LoadUint64(&self.stats.panics)
+	if last := atomic.LoadInt64(&self.stats.last); last != 0 {
+		stats.LastCalled = time.Unix(0, last)
+	}
+	return stats
+}
+
+// Stats() - the invocations of the subscribed listener, they're kept once it's removed
+func (self *Subscription) Stats() ListenerStats {
+	event := ""
+	if keys := self.keys(); len(keys) > 0 {
+		event = keys[0]
+	}
+
+	self.emitter.mutex.Lock()
+	if self.stats == nil {
+		for _, lis := range self.emitter.listeners {
+			for _, l := range lis {
+				if l.id == self.id {
+					self.stats = l.stats
+				}
+			}
+		}
+	}
+	stats := self.stats
+	self.emitter.mutex.Unlock()
+	return Listener{id: self.id, event: event, stats: stats}.Stats()
+}
+
+// ListenerStats() - the invocations of all the registered listeners, in their registration order
+func (self *Emitter) ListenerStats() []ListenerStats {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	stats := []ListenerStats{}
+	seen := map[uint64]bool{}
+	for _, lis := range self.listeners {
+		for _, l := range lis {
+			if !seen[l.id] {
+				seen[l.id] = true
+				stats = append(stats, l.Stats())
+			}
+		}
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].ID < stats[j].ID })
+	return stats
+}
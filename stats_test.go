@@ -0,0 +1,53 @@
+package Emitter
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListenerStats(t *testing.T) {
+	clock := NewFakeClock(time.Unix(1000, 0))
+	emitter := New(WithClock(clock))
+
+	hot := emitter.Subscribe("tick", func(args ...interface{}) {})
+	dead := emitter.Subscribe("never", func(args ...interface{}) {})
+	multi := emitter.OnPatterns([]string{"tick", "tock"}, func(args ...interface{}) {})
+
+	emitter.EmitSync("tick")
+	clock.Advance(time.Second)
+	emitter.EmitSync("tick")
+	emitter.EmitSync("tock")
+
+	expect(t, uint64(2), hot.Stats().Calls)
+	expect(t, time.Unix(1001, 0), hot.Stats().LastCalled)
+	expect(t, uint64(0), dead.Stats().Calls)
+	expect(t, true, dead.Stats().LastCalled.IsZero(), "never called")
+	expect(t, uint64(3), multi.Stats().Calls, "the patterns of a registration share their counters")
+
+	all := emitter.ListenerStats()
+	expect(t, 3, len(all))
+	expect(t, hot.ID(), all[0].ID)
+	expect(t, "never", all[1].Event)
+	expect(t, uint64(2), emitter.Subscription(hot.ID()).Stats().Calls)
+
+	clone := emitter.Clone()
+	clone.EmitSync("tick")
+	expect(t, uint64(2), hot.Stats().Calls, "the clone counts its own invocations")
+	expect(t, uint64(1), clone.ListenerStats()[0].Calls)
+
+	hot.Remove()
+	expect(t, uint64(2), hot.Stats().Calls, "kept once removed")
+}
+
+func TestListenerStatsAsync(t *testing.T) {
+	emitter := New()
+	done := make(chan bool, 10)
+	sub := emitter.Subscribe("job", func(args ...interface{}) { done <- true })
+	for i := 0; i < 10; i++ {
+		emitter.EmitAsync("job", nil)
+	}
+	for i := 0; i < 10; i++ {
+		<-done
+	}
+	expect(t, uint64(10), sub.Stats().Calls)
+}
@@ -0,0 +1,69 @@
+package Emitter
+
+// SubscriptionID - the identity of a registration, assigned in sequence: unlike the function pointers
+// RemoveListener compares, it tells apart the method values and the closures wrapping the same code
+type SubscriptionID uint64
+
+// ID() - the identity of the subscribed registration, 0 if it was rejected (reserved event)
+func (self *Subscription) ID() SubscriptionID {
+	return SubscriptionID(self.id)
+}
+
+// ID() - the identity of the listener's registration
+func (self Listener) ID() SubscriptionID {
+	return SubscriptionID(self.id)
+}
+
+// Subscribe() - register a new listener on the specified event, its subscription removes it
+func (self *Emitter) Subscribe(event string, callback func(...interface{})) *Subscription {
+	return self.addListenerInternal(event, Listener{callback: callback})
+}
+
+// SubscribeOnce() - register a new one-time listener on the specified event, its subscription removes it
+func (self *Emitter) SubscribeOnce(event string, callback func(...interface{})) *Subscription {
+	return self.addListenerInternal(event, Listener{callback: callback, once: true})
+}
+
+// Subscription() - the handle of the registration, nil if it's no longer registered
+func (self *Emitter) Subscription(id SubscriptionID) *Subscription {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	events := self.eventsOf(uint64(id))
+	switch len(events) {
+	case 0:
+		return nil
+	case 1:
+		return &Subscription{emitter: self, event: events[0], id: uint64(id)}
+	default:
+		return &Subscription{emitter: self, id: uint64(id), patterns: events}
+	}
+}
+
+// RemoveSubscription() - remove the listener of the registration from all the events it's on,
+// report whether it was still registered
+func (self *Emitter) RemoveSubscription(id SubscriptionID) bool {
+	self.mutex.Lock()
+	events := self.eventsOf(uint64(id))
+	self.mutex.Unlock()
+
+	if len(events) == 0 {
+		return false
+	}
+	self.removeEventsInternal(events, Listener{id: uint64(id)}.is)
+	return true
+}
+
+// eventsOf() - the events the registration is on, the mutex must be held
+func (self *Emitter) eventsOf(id uint64) []string {
+	events := []string{}
+	for event, lis := range self.listeners {
+		for _, l := range lis {
+			if l.id == id {
+				events = append(events, event.(string))
+				break
+			}
+		}
+	}
+	return events
+}
@@ -0,0 +1,44 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+type counter struct{ calls int }
+
+func (self *counter) inc(args ...interface{}) { self.calls++ }
+
+func TestSubscriptionID(t *testing.T) {
+	emitter := New()
+	a, b := &counter{}, &counter{}
+
+	// the method values of two receivers share the same function pointer
+	first := emitter.Subscribe("tick", a.inc)
+	second := emitter.Subscribe("tick", b.inc)
+	expect(t, true, first.ID() != second.ID())
+	expect(t, second.ID(), emitter.Listeners("tick")[1].ID())
+
+	expect(t, true, emitter.RemoveSubscription(second.ID()))
+	expect(t, false, emitter.RemoveSubscription(second.ID()), "already removed")
+	emitter.EmitSync("tick")
+	expect(t, 1, a.calls)
+	expect(t, 0, b.calls, "the right method value was removed")
+
+	expect(t, true, emitter.Subscription(second.ID()) == nil)
+	emitter.Subscription(first.ID()).Remove()
+	expect(t, 0, emitter.ListenersCount("tick"))
+}
+
+func TestSubscriptionIDOnPatterns(t *testing.T) {
+	emitter := New()
+	sub := emitter.OnPatterns([]string{"user.*", "order.*"}, func(args ...interface{}) {})
+	once := emitter.SubscribeOnce("user.created", func(args ...interface{}) {})
+	expect(t, 2, emitter.ListenersCount("user.created"))
+
+	expect(t, true, emitter.RemoveSubscription(sub.ID()))
+	expect(t, 0, emitter.ListenersCount("order.created"))
+	expect(t, 1, emitter.ListenersCount("user.created"))
+
+	emitter.EmitSync("user.created")
+	expect(t, false, emitter.RemoveSubscription(once.ID()), "the one-time listener is gone")
+}
@@ -0,0 +1,118 @@
+package Emitter
+
+import (
+	"sort"
+	"strings"
+)
+
+// HeaderTenant - the header holding the tenant of the events emitted on a tenant's emitter
+const HeaderTenant = "tenant"
+
+// TenantPrefix - the prefix of the events of the tenants on their parent emitter, "tenant.<id>.<event>"
+const TenantPrefix = "tenant."
+
+// tenancy - the tenant an emitter is the view of
+type tenancy struct {
+	parent *Emitter
+	id     string
+}
+
+// Tenant() - the isolated emitter of the tenant, the same one for the same id: its listeners only see
+// the tenant's events, which reach the parent, whatever the emit variant, tagged with the HeaderTenant
+// header and prefixed as "tenant.<id>.<event>", so admins subscribe across the tenants with i.e
+// "tenant.*.order.created".
+// The parent's emits of such names reach the tenant too. The id can't contain "."
+func (self *Emitter) Tenant(id string) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if tenant, ok := self.tenants[id]; ok {
+		return tenant
+	}
+	tenant := New()
+	tenant.tenancy = &tenancy{parent: self, id: id}
+	tenant.pool = self.pool
+	tenant.clock = self.clock
+	tenant.matcher = self.matcher
+	tenant.timeoutPolicy = self.timeoutPolicy
+	tenant.sourceDepth = self.sourceDepth
+	tenant.auth = self.auth
+	if self.tenants == nil {
+		self.tenants = map[string]*Emitter{}
+	}
+	self.tenants[id] = tenant
+	return tenant
+}
+
+// TenantID() - the tenant of the emitter, "" if it isn't a tenant's
+func (self *Emitter) TenantID() string {
+	if self.tenancy == nil {
+		return ""
+	}
+	return self.tenancy.id
+}
+
+// Tenants() - the ids of the tenants created on the emitter, sorted
+func (self *Emitter) Tenants() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	ids := make([]string, 0, len(self.tenants))
+	for id := range self.tenants {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// TenantEvent() - the name of the tenant's event on its parent
+func TenantEvent(id, event string) string {
+	return TenantPrefix + id + "." + event
+}
+
+// SplitTenantEvent() - the tenant and the event of a name prefixed by TenantEvent, false if it isn't
+func SplitTenantEvent(name string) (id, event string, ok bool) {
+	if !strings.HasPrefix(name, TenantPrefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(strings.TrimPrefix(name, TenantPrefix), ".", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// tagged() - the copy of the event with the tenant's header, named name
+func (self *tenancy) tagged(ev *Event, name string) *Event {
+	headers := make(map[string]string, len(ev.Headers)+1)
+	for k, v := range ev.Headers {
+		headers[k] = v
+	}
+	headers[HeaderTenant] = self.id
+	out := *ev
+	out.Name = name
+	out.Headers = headers
+	return &out
+}
+
+// routeTenant() - deliver the tenant's emit to its parent, or the parent's emit of a tenant's event to the tenant
+func (self *Emitter) routeTenant(ev *Event, async bool) {
+	if IsMetaEvent(ev.Name) {
+		return
+	}
+	if self.tenancy != nil {
+		self.tenancy.parent.deliver(self.tenancy.tagged(ev, TenantEvent(self.tenancy.id, ev.Name)), async)
+		return
+	}
+
+	id, event, ok := SplitTenantEvent(ev.Name)
+	if !ok {
+		return
+	}
+	self.mutex.Lock()
+	tenant := self.tenants[id]
+	self.mutex.Unlock()
+	if tenant != nil {
+		tenant.deliver(tenant.tenancy.tagged(ev, event), async)
+	}
+}
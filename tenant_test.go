@@ -0,0 +1,72 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestTenant(t *testing.T) {
+	root := New()
+	acme, globex := root.Tenant("acme"), root.Tenant("globex")
+	expect(t, acme, root.Tenant("acme"))
+	expect(t, "acme", acme.TenantID())
+	expect(t, "", root.TenantID())
+	expect(t, 2, len(root.Tenants()))
+
+	acmeEvents, globexEvents, admin := []*Event{}, []*Event{}, []*Event{}
+	acme.OnEvent("order.*", func(ev *Event) { acmeEvents = append(acmeEvents, ev) })
+	globex.OnEvent("order.*", func(ev *Event) { globexEvents = append(globexEvents, ev) })
+	root.OnEvent("tenant.*.order.created", func(ev *Event) { admin = append(admin, ev) })
+
+	acme.EmitSync("order.created", 42)
+	expect(t, 1, len(acmeEvents))
+	expect(t, 0, len(globexEvents), "isolated from the other tenants")
+	expect(t, "acme", acmeEvents[0].Headers[HeaderTenant])
+	expect(t, 1, len(admin))
+	expect(t, "tenant.acme.order.created", admin[0].Name)
+	expect(t, "acme", admin[0].Headers[HeaderTenant])
+	expect(t, 42, admin[0].Args[0])
+
+	// the admins address a tenant through its prefix
+	root.EmitSync(TenantEvent("globex", "order.cancelled"))
+	expect(t, 1, len(globexEvents))
+	expect(t, "order.cancelled", globexEvents[0].Name)
+	expect(t, 1, len(acmeEvents))
+
+	// the root's own events don't reach the tenants
+	root.EmitSync("order.created")
+	expect(t, 1, len(acmeEvents))
+	expect(t, 1, len(globexEvents))
+}
+
+func TestTenantEmitVariants(t *testing.T) {
+	root := New()
+	acme := root.Tenant("acme")
+	tagged, admin := 0, []string{}
+	acme.OnEvent("order.*", func(ev *Event) {
+		if ev.Headers[HeaderTenant] == "acme" {
+			tagged++
+		}
+	})
+	root.OnEvent("tenant.*.order.*", func(ev *Event) { admin = append(admin, ev.Name) })
+
+	acme.EmitFirst("order.created")
+	acme.EmitBalanced("order.created")
+	acme.EmitWith("order.created", nil, OnlyGroup(""))
+	acme.EmitMulti([]string{"order.created", "order.paid"})
+	acme.EmitReduce("order.paid", nil)
+	expect(t, 6, tagged, "the tenant's listeners get the tagged events")
+	expect(t, 6, len(admin), "the admin gets them all")
+	expect(t, "tenant.acme.order.paid", admin[5])
+}
+
+func TestSplitTenantEvent(t *testing.T) {
+	id, event, ok := SplitTenantEvent("tenant.acme.user.created")
+	expect(t, true, ok)
+	expect(t, "acme", id)
+	expect(t, "user.created", event)
+
+	_, _, ok = SplitTenantEvent("tenant.acme")
+	expect(t, false, ok)
+	_, _, ok = SplitTenantEvent("user.created")
+	expect(t, false, ok)
+}
@@ -0,0 +1,116 @@
+package Emitter
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// TimeoutPolicy - what EmitSyncTimeout does with the listeners left once its timeout is exhausted
+type TimeoutPolicy int
+
+const (
+	// TimeoutSkip - don't run the listeners not started yet, the default
+	TimeoutSkip TimeoutPolicy = iota
+	// TimeoutBackground - keep running the remaining listeners in the background
+	TimeoutBackground
+)
+
+// TimeoutError - the error of an EmitSyncTimeout exceeding its timeout
+type TimeoutError struct {
+	Event   string
+	Timeout time.Duration
+	// Skipped - the listeners that didn't run, or that run in the background, per the policy
+	Skipped []Listener
+	Policy  TimeoutPolicy
+}
+
+func (self *TimeoutError) Error() string {
+	action := "skipped"
+	if self.Policy == TimeoutBackground {
+		action = "left running in the background"
+	}
+	return fmt.Sprintf("emitter: %s timed out after %v, %d listeners %s", self.Event, self.Timeout, len(self.Skipped), action)
+}
+
+// SetTimeoutPolicy() - choose what EmitSyncTimeout does with the listeners left once timed out
+func (self *Emitter) SetTimeoutPolicy(policy TimeoutPolicy) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.timeoutPolicy = policy
+	return self
+}
+
+// EmitSyncTimeout() - run all listeners of the event one after the other, as EmitSync does, but
+// stop waiting for them once the timeout is exhausted, returning a *TimeoutError listing the
+// listeners skipped, or still to complete in the background, per the emitter's TimeoutPolicy.
+// The listener running at the timeout can't be interrupted, it completes in the background.
+func (self *Emitter) EmitSyncTimeout(event string, timeout time.Duration, args ...interface{}) error {
+	var timedOut error
+	err := self.dispatchWith(&Event{Name: event, Args: args}, false, func(ev *Event, async bool) int {
+		if !self.admit(ev) {
+			return -1
+		}
+		n, err := self.runTimeout(ev, timeout)
+		timedOut = err
+		return n
+	})
+	if err != nil {
+		return err
+	}
+	return timedOut
+}
+
+// runTimeout() - run the listeners of the event one after the other until the timeout is exhausted,
+// how many were started and the *TimeoutError of the timeout, if exhausted
+func (self *Emitter) runTimeout(ev *Event, timeout time.Duration) (int, error) {
+	self.mutex.Lock()
+	policy := self.timeoutPolicy
+	self.mutex.Unlock()
+
+	listeners := self.listenersOf(ev.Name)
+	mutex := &sync.Mutex{}
+	next, aborted := 0, false
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for {
+			mutex.Lock()
+			if next == len(listeners) || (aborted && policy == TimeoutSkip) {
+				mutex.Unlock()
+				return
+			}
+			v := listeners[next]
+			next++
+			mutex.Unlock()
+
+			if v.once && !self.claimOnce(v) {
+				continue
+			}
+			v.call(ev)
+		}
+	}()
+
+	expired := make(chan struct{})
+	timer := self.Clock().AfterFunc(timeout, func() { close(expired) })
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return len(listeners), nil
+	case <-expired:
+	}
+
+	mutex.Lock()
+	defer mutex.Unlock()
+
+	aborted = true
+	skipped := next
+	if policy == TimeoutBackground && next > 0 {
+		// the one running completes in the background too
+		skipped--
+	}
+	return skipped, &TimeoutError{Event: ev.Name, Timeout: timeout, Skipped: append([]Listener{}, listeners[skipped:]...), Policy: policy}
+}
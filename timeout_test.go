@@ -0,0 +1,44 @@
+package Emitter
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEmitSyncTimeout(t *testing.T) {
+	emitter := Construct()
+
+	ran := []int{}
+	mutex := sync.Mutex{}
+	for i := 0; i < 3; i++ {
+		i := i
+		emitter.On("slow", func(args ...interface{}) {
+			time.Sleep(30 * time.Millisecond)
+			mutex.Lock()
+			ran = append(ran, i)
+			mutex.Unlock()
+		})
+	}
+
+	expect(t, nil, emitter.EmitSyncTimeout("nobody", time.Millisecond))
+
+	err := emitter.EmitSyncTimeout("slow", 10*time.Millisecond)
+	timeout, ok := err.(*TimeoutError)
+	expect(t, true, ok)
+	expect(t, 2, len(timeout.Skipped))
+
+	time.Sleep(50 * time.Millisecond)
+	mutex.Lock()
+	expect(t, 1, len(ran), "the first one completed, the others were skipped")
+	ran = ran[:0]
+	mutex.Unlock()
+
+	emitter.SetTimeoutPolicy(TimeoutBackground)
+	err = emitter.EmitSyncTimeout("slow", 10*time.Millisecond)
+	expect(t, 3, len(err.(*TimeoutError).Skipped))
+	time.Sleep(120 * time.Millisecond)
+	mutex.Lock()
+	expect(t, 3, len(ran), "all of them completed in the background")
+	mutex.Unlock()
+}
@@ -0,0 +1,39 @@
+package Emitter
+
+import (
+	"context"
+	"sort"
+)
+
+// CloseTree() - close the emitter after its tenants' emitters, the children before their parent,
+// each one emitting EventClosing then EventClosed so its listeners clean up in order. If the context
+// is done meanwhile the emitters not closed yet stay open and its error is returned
+func (self *Emitter) CloseTree(ctx context.Context) error {
+	self.mutex.Lock()
+	ids := make([]string, 0, len(self.tenants))
+	for id := range self.tenants {
+		ids = append(ids, id)
+	}
+	self.mutex.Unlock()
+
+	sort.Strings(ids)
+	for _, id := range ids {
+		self.mutex.Lock()
+		tenant := self.tenants[id]
+		self.mutex.Unlock()
+		if tenant == nil {
+			continue
+		}
+		if err := tenant.CloseTree(ctx); err != nil {
+			return err
+		}
+		self.mutex.Lock()
+		delete(self.tenants, id)
+		self.mutex.Unlock()
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return self.Close()
+}
@@ -0,0 +1,45 @@
+package Emitter
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCloseTree(t *testing.T) {
+	root := New()
+	acme, globex := root.Tenant("acme"), root.Tenant("globex")
+
+	order := []string{}
+	for _, e := range []*Emitter{root, acme, globex} {
+		e.On(EventClosing, func(args ...interface{}) {
+			order = append(order, "closing:"+args[0].(*Emitter).TenantID())
+		})
+		e.On(EventClosed, func(args ...interface{}) {
+			order = append(order, "closed:"+args[0].(*Emitter).TenantID())
+		})
+	}
+	all := 0
+	root.On("emitter.*", func(args ...interface{}) { all++ })
+
+	expect(t, nil, root.CloseTree(context.Background()))
+	expect(t, 6, len(order))
+	expect(t, "closing:acme", order[0])
+	expect(t, "closed:acme", order[1])
+	expect(t, "closing:globex", order[2])
+	expect(t, "closed:", order[5], "the parent closes last")
+	expect(t, 0, len(root.Tenants()))
+	expect(t, 2, all, "the lifecycle events of the tenants aren't routed to the parent")
+}
+
+func TestCloseTreeCancelled(t *testing.T) {
+	root := New()
+	root.Tenant("acme")
+	closed := 0
+	root.On(EventClosed, func(args ...interface{}) { closed++ })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	expect(t, context.Canceled, root.CloseTree(ctx))
+	expect(t, 0, closed)
+	expect(t, 1, len(root.Tenants()), "the tenant stays open")
+}
@@ -0,0 +1,51 @@
+package Emitter
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// HeaderExpires - the header of the time after which the undelivered event is discarded, RFC 3339 formatted, see EmitTTL
+const HeaderExpires = "expires"
+
+// Expires() - the time after which the undelivered event is discarded, zero if it never is
+func (self *Event) Expires() time.Time {
+	expires, err := time.Parse(time.RFC3339Nano, self.Headers[HeaderExpires])
+	if err != nil {
+		return time.Time{}
+	}
+	return expires
+}
+
+// EmitTTL() - run all listeners of the event in synchronous mode, unless it's held by the paused emitter
+// for longer than the ttl: it's then discarded, raising an "eventExpired" meta-event with its name and itself
+func (self *Emitter) EmitTTL(event string, ttl time.Duration, args ...interface{}) *Emitter {
+	self.dispatch(self.expiring(event, ttl, args), false)
+	return self
+}
+
+// EmitAsyncTTL() - run all listeners of the event in asynchronous mode, as EmitAsync does, the invocations
+// still queued in the pool or held by the paused emitter after the ttl being discarded, raising an
+// "eventExpired" meta-event with its name and itself
+func (self *Emitter) EmitAsyncTTL(event string, ttl time.Duration, args ...interface{}) *Emitter {
+	self.dispatch(self.expiring(event, ttl, args), true)
+	return self
+}
+
+// expiring() - the event expiring after the ttl
+func (self *Emitter) expiring(event string, ttl time.Duration, args []interface{}) *Event {
+	expires := self.Clock().Now().Add(ttl).Format(time.RFC3339Nano)
+	return &Event{Name: event, Args: args, Headers: map[string]string{HeaderExpires: expires}, discarded: new(int32)}
+}
+
+// expired() - whether the event expired, raising the "eventExpired" meta-event once
+func (self *Emitter) expired(ev *Event) bool {
+	expires := ev.Expires()
+	if expires.IsZero() || self.Clock().Now().Before(expires) {
+		return false
+	}
+	if ev.discarded == nil || atomic.CompareAndSwapInt32(ev.discarded, 0, 1) {
+		self.emitMeta("eventExpired", ev.Name, ev)
+	}
+	return true
+}
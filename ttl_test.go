@@ -0,0 +1,85 @@
+package Emitter
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestEmitAsyncTTL(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	pool := NewPool(1)
+	defer pool.Close()
+	emitter := New(WithClock(clock), WithPool(pool))
+
+	mutex := &sync.Mutex{}
+	delivered, expired := 0, []string{}
+	count := func(args ...interface{}) {
+		mutex.Lock()
+		delivered++
+		mutex.Unlock()
+	}
+	emitter.On("user.created", count)
+	emitter.On("user.*", count)
+	emitter.On("eventExpired", func(args ...interface{}) {
+		mutex.Lock()
+		expired = append(expired, args[0].(string))
+		mutex.Unlock()
+	})
+
+	release := make(chan bool)
+	pool.Submit(func() { <-release })
+	emitter.EmitAsyncTTL("user.created", time.Second)
+	emitter.EmitAsyncTTL("user.updated", time.Minute)
+	clock.Advance(2 * time.Second)
+	close(release)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	pool.Submit(wg.Done)
+	wg.Wait()
+	expect(t, 1, delivered, "the queued invocations of the expired event are discarded")
+	expect(t, 1, len(expired), "the expired event is reported once")
+	expect(t, "user.created", expired[0])
+}
+
+func TestEmitTTLPaused(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	emitter := New(WithClock(clock))
+	delivered, expired := []string{}, 0
+	emitter.On("user.*", func(args ...interface{}) { delivered = append(delivered, args[0].(string)) })
+	emitter.On("eventExpired", func(args ...interface{}) { expired++ })
+
+	emitter.EmitTTL("user.created", time.Second, "a")
+	expect(t, 1, len(delivered), "the emits of a running emitter are delivered")
+
+	emitter.Pause()
+	emitter.EmitTTL("user.created", time.Second, "b")
+	emitter.EmitTTL("user.created", time.Hour, "c")
+	emitter.EmitSync("user.created", "d")
+	clock.Advance(time.Minute)
+	emitter.Resume()
+	expect(t, "a,c,d", strings.Join(delivered, ","))
+	expect(t, 1, expired)
+
+	ev := &Event{Name: "user.created", Headers: map[string]string{HeaderExpires: "2020-01-02T03:04:05Z"}}
+	expect(t, 2020, ev.Expires().Year())
+	expect(t, true, (&Event{}).Expires().IsZero())
+}
+
+func TestEmitTTLTenant(t *testing.T) {
+	clock := NewFakeClock(time.Unix(0, 0))
+	root := New(WithClock(clock))
+	acme := root.Tenant("acme")
+	delivered, expired := 0, 0
+	acme.On("user.created", func(args ...interface{}) { delivered++ })
+	acme.On("eventExpired", func(args ...interface{}) { expired++ })
+
+	acme.Pause()
+	acme.EmitTTL("user.created", time.Second)
+	clock.Advance(time.Minute)
+	acme.Resume()
+	expect(t, 0, delivered)
+	expect(t, 1, expired, "the tenant's expired event is reported once")
+}
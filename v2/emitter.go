@@ -0,0 +1,103 @@
+// Package emitter is the v2 surface of goemitter, with Go idiomatic naming:
+// New instead of Construct, Close instead of Destruct and an EmitAsync
+// variadic like EmitSync.
+//
+// It wraps the v1 Emitter, whose other methods are promoted as they are, so
+// both APIs can be mixed while migrating:
+//
+//	e := emitter.New()
+//	defer e.Close()
+//	e.On("user.created", fn)
+//	e.EmitAsync("user.created", user)
+package emitter
+
+import (
+	goemitter "github.com/moleculer-go/goemitter"
+)
+
+type (
+	// Event - the envelope of a single emit, as seen by event listeners
+	Event = goemitter.Event
+	// Listener - a registered listener
+	Listener = goemitter.Listener
+	// Subscription - the handle of a registered listener
+	Subscription = goemitter.Subscription
+	// Option - a setting of the emitter, applied by New
+	Option = goemitter.Option
+)
+
+// Emitter - the v2 emitter, the v1 one with the idiomatic methods
+type Emitter struct {
+	*goemitter.Emitter
+}
+
+// New() - create a new emitter with the options applied
+func New(opts ...Option) *Emitter {
+	return &Emitter{goemitter.New(opts...)}
+}
+
+// Wrap() - the v2 surface of an existing v1 emitter
+func Wrap(e *goemitter.Emitter) *Emitter {
+	return &Emitter{e}
+}
+
+// Match() - report whether the event name matches the (wildcard) pattern
+func Match(pattern, event string) bool {
+	return goemitter.Match(pattern, event)
+}
+
+// On() - register a new listener on the event
+func (e *Emitter) On(event string, callback func(...interface{})) *Emitter {
+	e.Emitter.On(event, callback)
+	return e
+}
+
+// Once() - register a new one-time listener on the event
+func (e *Emitter) Once(event string, callback func(...interface{})) *Emitter {
+	e.Emitter.Once(event, callback)
+	return e
+}
+
+// Off() - remove the callback from the listeners of the event, or of the names the pattern matches
+func (e *Emitter) Off(event string, callback func(...interface{})) *Emitter {
+	e.Emitter.Off(event, callback)
+	return e
+}
+
+// RemoveListener() - remove the callback from the event's listeners
+func (e *Emitter) RemoveListener(event string, callback func(...interface{})) *Emitter {
+	return e.Off(event, callback)
+}
+
+// RemoveAllListeners() - remove all the listeners of the events, or of all the events if none is given
+func (e *Emitter) RemoveAllListeners(events ...string) *Emitter {
+	if len(events) == 0 {
+		e.Emitter.RemoveAllListeners(nil)
+	}
+	for _, event := range events {
+		e.Emitter.RemoveAllListeners(event)
+	}
+	return e
+}
+
+// Emit() - run all the listeners of the event in synchronous mode
+func (e *Emitter) Emit(event string, args ...interface{}) *Emitter {
+	return e.EmitSync(event, args...)
+}
+
+// EmitSync() - run all the listeners of the event in synchronous mode
+func (e *Emitter) EmitSync(event string, args ...interface{}) *Emitter {
+	e.Emitter.EmitSync(event, args...)
+	return e
+}
+
+// EmitAsync() - run all the listeners of the event in asynchronous mode using goroutines
+func (e *Emitter) EmitAsync(event string, args ...interface{}) *Emitter {
+	e.Emitter.EmitAsync(event, args)
+	return e
+}
+
+// V1() - the wrapped v1 emitter
+func (e *Emitter) V1() *goemitter.Emitter {
+	return e.Emitter
+}
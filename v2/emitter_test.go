@@ -0,0 +1,47 @@
+package emitter
+
+import (
+	"sync"
+	"testing"
+
+	goemitter "github.com/moleculer-go/goemitter"
+)
+
+func TestEmitAsyncVariadic(t *testing.T) {
+	e := New()
+	defer e.Close()
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	got := []interface{}{}
+	e.On("user.created", func(args ...interface{}) {
+		got = args
+		wg.Done()
+	})
+
+	e.EmitAsync("user.created", "john", 42)
+	wg.Wait()
+
+	expect(t, 2, len(got))
+	expect(t, "john", got[0])
+	expect(t, 42, got[1])
+}
+
+func TestChaining(t *testing.T) {
+	v1 := goemitter.Construct()
+	e := Wrap(v1)
+
+	count := 0
+	fn := func(args ...interface{}) { count++ }
+	e.On("a", fn).Once("a", fn).Emit("a").Emit("a").Off("a", fn).Emit("a")
+
+	expect(t, 3, count)
+	expect(t, 0, v1.ListenersCount("a"))
+	expect(t, v1, e.V1())
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}
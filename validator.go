@@ -0,0 +1,77 @@
+package Emitter
+
+import (
+	"fmt"
+)
+
+// validator - checks the args of the emits of the events matching the pattern
+type validator struct {
+	pattern string
+	check   func(args []interface{}) error
+}
+
+// SetValidator() - check the args of the emits of the events matching the pattern, the invalid
+// emits are rejected: reported by a "schemaViolation" meta-event and handed to the dead-letter
+// handler, if any, instead of being delivered; a nil validator removes the pattern's one.
+// It catches the producers' bugs early, see RegisterEvent for the declared arg types
+func (self *Emitter) SetValidator(pattern string, check func(args []interface{}) error) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	pattern = self.key(pattern)
+	validators := self.validators[:0:0]
+	for _, v := range self.validators {
+		if v.pattern != pattern {
+			validators = append(validators, v)
+		}
+	}
+	if check != nil {
+		validators = append(validators, validator{pattern, check})
+	}
+	self.validators = validators
+	return self
+}
+
+// SetDeadLetter() - hand the emits rejected by the validators to the handler, with their error
+func (self *Emitter) SetDeadLetter(handler func(ev *Event, err error)) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.deadLetters = handler
+	return self
+}
+
+// validatorError() - the error of the first validator of the event rejecting the args
+func (self *Emitter) validatorError(event string, args []interface{}) error {
+	self.mutex.Lock()
+	if len(self.validators) == 0 {
+		self.mutex.Unlock()
+		return nil
+	}
+	key := self.key(event)
+	checks := []validator{}
+	for _, v := range self.validators {
+		if self.compiled(v.pattern).match(key) {
+			checks = append(checks, v)
+		}
+	}
+	self.mutex.Unlock()
+
+	for _, v := range checks {
+		if err := v.check(args); err != nil {
+			return fmt.Errorf("%s: %v", event, err)
+		}
+	}
+	return nil
+}
+
+// deadLetter() - hand the rejected emit to the dead-letter handler, if any
+func (self *Emitter) deadLetter(ev *Event, err error) {
+	self.mutex.Lock()
+	handler := self.deadLetters
+	self.mutex.Unlock()
+
+	if handler != nil {
+		handler(ev, err)
+	}
+}
@@ -0,0 +1,42 @@
+package Emitter
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSetValidator(t *testing.T) {
+	emitter := New()
+	errNoID := errors.New("missing id")
+	emitter.SetValidator("user.*", func(args []interface{}) error {
+		if len(args) == 0 {
+			return errNoID
+		}
+		return nil
+	})
+
+	delivered, violations := 0, 0
+	var dead *Event
+	var deadErr error
+	emitter.On("user.created", func(args ...interface{}) { delivered++ })
+	emitter.On("order.created", func(args ...interface{}) { delivered++ })
+	emitter.On("schemaViolation", func(args ...interface{}) { violations++ })
+
+	emitter.EmitSync("user.created", 42)
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("order.created")
+	expect(t, 2, delivered, "the invalid emit is rejected")
+	expect(t, 1, violations)
+
+	emitter.SetDeadLetter(func(ev *Event, err error) { dead, deadErr = ev, err })
+	emitter.EmitSync("user.created")
+	expect(t, "user.created", dead.Name)
+	expect(t, "user.created: missing id", deadErr.Error())
+
+	expect(t, "user.created: missing id", emitter.EmitValidated("user.created").Error())
+	expect(t, nil, emitter.ValidateArgs("user.created", []interface{}{1}))
+
+	emitter.SetValidator("user.*", nil)
+	emitter.EmitSync("user.created")
+	expect(t, 3, delivered, "the validator is removed")
+}
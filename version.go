@@ -0,0 +1,90 @@
+package Emitter
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+)
+
+// HeaderVersion - the header of the version of the event's args, set by EmitVersion
+const HeaderVersion = "version"
+
+// Migration - upgrade the args of an event by one version
+type Migration func(args []interface{}) ([]interface{}, error)
+
+// RegisterMigration() - declare how the args of the event are upgraded from the version to the next one,
+// applied for the listeners subscribed with OnVersion to a newer version than the producers emit
+func (self *Emitter) RegisterMigration(event string, from int, migration Migration) *Emitter {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	if self.migrations == nil {
+		self.migrations = make(map[string]map[int]Migration)
+	}
+	if self.migrations[event] == nil {
+		self.migrations[event] = make(map[int]Migration)
+	}
+	self.migrations[event][from] = migration
+	return self
+}
+
+// Migrate() - the args of the event upgraded from the version to the newer one, through each migration between them
+func (self *Emitter) Migrate(event string, args []interface{}, from, to int) ([]interface{}, error) {
+	if from > to {
+		return nil, fmt.Errorf("emitter: %s can't be downgraded from v%d to v%d", event, from, to)
+	}
+
+	self.mutex.Lock()
+	migrations := self.migrations[event]
+	self.mutex.Unlock()
+
+	args = append([]interface{}{}, args...)
+	for v := from; v < to; v++ {
+		migration, ok := migrations[v]
+		if !ok {
+			return nil, fmt.Errorf("emitter: %s has no migration from v%d", event, v)
+		}
+		var err error
+		if args, err = migration(args); err != nil {
+			return nil, fmt.Errorf("emitter: %s migration from v%d: %v", event, v, err)
+		}
+	}
+	return args, nil
+}
+
+// EmitVersion() - run all listeners of the event in synchronous mode, its args being of the version
+func (self *Emitter) EmitVersion(event string, version int, args ...interface{}) *Emitter {
+	return self.EmitEvent(&Event{Name: event, Args: args, Headers: map[string]string{HeaderVersion: strconv.Itoa(version)}})
+}
+
+// OnVersion() - register a new listener expecting the args of the event's version: the args of
+// the emits of an older version are migrated first, see RegisterMigration. The emits are of the
+// version of their HeaderVersion, else of their schema's, the ones failing to migrate raise a
+// "schemaViolation" meta-event and the callback isn't called
+func (self *Emitter) OnVersion(event string, version int, callback func(...interface{})) *Subscription {
+	handler := func(ev *Event) {
+		from := self.versionOf(ev)
+		if from == version {
+			callback(ev.Args...)
+			return
+		}
+		args, err := self.Migrate(ev.Name, ev.Args, from, version)
+		if err != nil {
+			self.emitMeta("schemaViolation", ev.Name, err)
+			return
+		}
+		callback(args...)
+	}
+	return self.addListenerInternal(event, Listener{handler: handler, origin: reflect.ValueOf(callback).Pointer()})
+}
+
+// versionOf() - the version of the event's args
+func (self *Emitter) versionOf(ev *Event) int {
+	if v, ok := ev.Headers[HeaderVersion]; ok {
+		if version, err := strconv.Atoi(v); err == nil {
+			return version
+		}
+	}
+	schema, _ := self.Schema(ev.Name)
+	return schema.Version
+}
@@ -0,0 +1,48 @@
+package Emitter
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOnVersion(t *testing.T) {
+	emitter := New()
+	emitter.RegisterEvent("user.created", Schema{Variadic: true, Version: 1})
+	// v1: name - v2: first name, last name - v3: the full name and the admin flag
+	emitter.RegisterMigration("user.created", 1, func(args []interface{}) ([]interface{}, error) {
+		return []interface{}{args[0], ""}, nil
+	})
+	emitter.RegisterMigration("user.created", 2, func(args []interface{}) ([]interface{}, error) {
+		if args[0] == "" {
+			return nil, errors.New("no name")
+		}
+		return []interface{}{fmt.Sprint(args[0], " ", args[1]), false}, nil
+	})
+
+	v1, v3 := []interface{}{}, []interface{}{}
+	violations := 0
+	emitter.OnVersion("user.created", 1, func(args ...interface{}) { v1 = args })
+	emitter.OnVersion("user.created", 3, func(args ...interface{}) { v3 = args })
+	emitter.On("schemaViolation", func(args ...interface{}) { violations++ })
+
+	emitter.EmitSync("user.created", "john")
+	expect(t, "john", v1[0], "the emit is of the schema's version")
+	expect(t, 2, len(v3))
+	expect(t, "john ", v3[0])
+	expect(t, false, v3[1])
+
+	emitter.EmitVersion("user.created", 2, "jane", "doe")
+	expect(t, "jane doe", v3[0])
+	expect(t, 1, violations, "the v1 listener can't get the v2 args")
+
+	emitter.EmitVersion("user.created", 3, "jim doe", true)
+	expect(t, true, v3[1])
+
+	emitter.EmitVersion("user.created", 2, "", "doe")
+	expect(t, 4, violations)
+	expect(t, true, v3[1], "the failed migrations don't call the listener")
+
+	_, err := emitter.Migrate("user.created", nil, 0, 1)
+	expect(t, "emitter: user.created has no migration from v0", err.Error())
+}
@@ -0,0 +1,106 @@
+package Emitter
+
+// EventSource - anything events can be listened on, an Emitter or a View
+type EventSource interface {
+	OnEvent(event string, handler func(*Event)) *Subscription
+}
+
+// View - a read-only emitter whose events come from sources, transformed or combined
+type View struct {
+	emitter *Emitter
+	sources []*Subscription
+}
+
+// newView() - a view re-emitting the transformed events of the sources, nil transformed events are dropped
+func newView(transform func(*Event) *Event, sources ...EventSource) *View {
+	view := &View{emitter: Construct()}
+	for _, source := range sources {
+		view.sources = append(view.sources, source.OnEvent("**", func(ev *Event) {
+			// the sources' meta-events are theirs, the view has its own
+			if IsMetaEvent(ev.Name) {
+				return
+			}
+			if ev = transform(ev); ev != nil {
+				view.emitter.EmitEvent(ev)
+			}
+		}))
+	}
+	return view
+}
+
+// Merge() - a view of the events of all the sources
+func Merge(sources ...EventSource) *View {
+	return newView(func(ev *Event) *Event {
+		return ev
+	}, sources...)
+}
+
+// Filtered() - a view of the events accepted by the predicate
+func (self *Emitter) Filtered(predicate func(*Event) bool) *View {
+	return newView(filter(predicate), self)
+}
+
+// Mapped() - a view of the events transformed by fn, events mapped to nil are dropped
+func (self *Emitter) Mapped(fn func(*Event) *Event) *View {
+	return newView(fn, self)
+}
+
+// Filtered() - a view of the view's events accepted by the predicate
+func (self *View) Filtered(predicate func(*Event) bool) *View {
+	return newView(filter(predicate), self)
+}
+
+// Mapped() - a view of the view's events transformed by fn, events mapped to nil are dropped
+func (self *View) Mapped(fn func(*Event) *Event) *View {
+	return newView(fn, self)
+}
+
+// On() - register a new listener on the specified event of the view
+func (self *View) On(event string, callback func(...interface{})) *View {
+	self.emitter.On(event, callback)
+	return self
+}
+
+// Once() - register a new one-time listener on the specified event of the view
+func (self *View) Once(event string, callback func(...interface{})) *View {
+	self.emitter.Once(event, callback)
+	return self
+}
+
+// OnEvent() - register a new listener receiving the whole event envelope on the specified event of the view
+func (self *View) OnEvent(event string, handler func(*Event)) *Subscription {
+	return self.emitter.OnEvent(event, handler)
+}
+
+// RemoveListener() - remove the specified callback from the specified events' listeners of the view
+func (self *View) RemoveListener(event string, callback func(...interface{})) *View {
+	self.emitter.RemoveListener(event, callback)
+	return self
+}
+
+// Listeners() - return an array with the registered listeners of the view in the specified event
+func (self *View) Listeners(event string) []Listener {
+	return self.emitter.Listeners(event)
+}
+
+// ListenersCount() - return the count of listeners of the view in the specified event
+func (self *View) ListenersCount(event string) int {
+	return self.emitter.ListenersCount(event)
+}
+
+// Close() - detach the view from its sources, it doesn't receive events anymore
+func (self *View) Close() {
+	for _, source := range self.sources {
+		source.Remove()
+	}
+	self.sources = nil
+}
+
+func filter(predicate func(*Event) bool) func(*Event) *Event {
+	return func(ev *Event) *Event {
+		if predicate(ev) {
+			return ev
+		}
+		return nil
+	}
+}
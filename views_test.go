@@ -0,0 +1,37 @@
+package Emitter
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestViews(t *testing.T) {
+	users, accounts := Construct(), Construct()
+
+	merged := Merge(users, accounts)
+	upper := merged.
+		Filtered(func(ev *Event) bool { return ev.Name != "user.heartbeat" }).
+		Mapped(func(ev *Event) *Event {
+			return &Event{Name: strings.ToUpper(ev.Name), Args: ev.Args}
+		})
+
+	names := []string{}
+	upper.OnEvent("**", func(ev *Event) {
+		if !IsMetaEvent(ev.Name) {
+			names = append(names, ev.Name)
+		}
+	})
+
+	users.EmitSync("user.created")
+	users.EmitSync("user.heartbeat")
+	accounts.EmitSync("account.created")
+
+	expect(t, 2, len(names))
+	expect(t, "USER.CREATED", names[0])
+	expect(t, "ACCOUNT.CREATED", names[1])
+
+	merged.Close()
+	users.EmitSync("user.created")
+	expect(t, 2, len(names))
+	expect(t, 0, users.ListenersCount("user.created"))
+}
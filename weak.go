@@ -0,0 +1,25 @@
+package Emitter
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+)
+
+// OnWeak() - register a new listener removed automatically once the owner becomes unreachable,
+// the owner must be a non nil pointer, it panics otherwise, and the callback must not reference it,
+// or it never becomes unreachable.
+// A finalizer is set on the owner, replacing the one it could have, and setting another one later
+// replaces this one, so the listener is never removed.
+func (self *Emitter) OnWeak(owner interface{}, event string, callback func(...interface{})) *Subscription {
+	// checked before registering, SetFinalizer panics only once the listener is added
+	if v := reflect.ValueOf(owner); v.Kind() != reflect.Ptr || v.IsNil() {
+		panic(fmt.Sprintf("emitter: OnWeak expects a non nil pointer owner, got %T", owner))
+	}
+	subscription := self.addListenerInternal(event, Listener{callback: callback})
+	runtime.SetFinalizer(owner, func(interface{}) {
+		// rejected if the emitter got frozen meanwhile, the listener stays
+		subscription.Remove()
+	})
+	return subscription
+}
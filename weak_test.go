@@ -0,0 +1,37 @@
+package Emitter
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestOnWeak(t *testing.T) {
+	emitter := Construct()
+
+	owner := &struct{ name string }{"widget"}
+	emitter.OnWeak(owner, "testevent", func(args ...interface{}) {})
+	expect(t, 1, emitter.ListenersCount("testevent"))
+
+	owner = nil
+	for i := 0; i < 100 && emitter.ListenersCount("testevent") > 0; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	expect(t, 0, emitter.ListenersCount("testevent"))
+}
+
+func TestOnWeakOwner(t *testing.T) {
+	emitter := Construct()
+
+	for _, owner := range []interface{}{struct{}{}, (*struct{})(nil), nil} {
+		func() {
+			defer func() {
+				expect(t, true, recover() != nil, fmt.Sprintf("panics for %T", owner))
+			}()
+			emitter.OnWeak(owner, "testevent", func(args ...interface{}) {})
+		}()
+	}
+	expect(t, 0, emitter.ListenersCount("testevent"), "nothing is registered")
+}
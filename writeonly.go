@@ -0,0 +1,42 @@
+package Emitter
+
+// PublisherOnly - the emitting side of an emitter, for the producer components: they can't register
+// listeners nor inspect them, which keeps the architectural boundaries
+type PublisherOnly struct {
+	emitter *Emitter
+}
+
+// WriteOnly() - the view of the emitter exposing its emit methods only
+func (self *Emitter) WriteOnly() PublisherOnly {
+	return PublisherOnly{self}
+}
+
+// EmitSync() - run all listeners of the specified event in synchronous mode
+func (self PublisherOnly) EmitSync(event string, args ...interface{}) PublisherOnly {
+	self.emitter.EmitSync(event, args...)
+	return self
+}
+
+// EmitEvent() - run all listeners of the event envelope in synchronous mode
+func (self PublisherOnly) EmitEvent(ev *Event) PublisherOnly {
+	self.emitter.EmitEvent(ev)
+	return self
+}
+
+// EmitAsync() - run all listeners of the specified event in asynchronous mode
+func (self PublisherOnly) EmitAsync(event string, args []interface{}) PublisherOnly {
+	self.emitter.EmitAsync(event, args)
+	return self
+}
+
+// EmitAsyncPriority() - run all listeners of the specified event in asynchronous mode, with the priority
+func (self PublisherOnly) EmitAsyncPriority(event string, priority int, args ...interface{}) PublisherOnly {
+	self.emitter.EmitAsyncPriority(event, priority, args...)
+	return self
+}
+
+// EmitMulti() - run the listeners of several events as one operation, see Emitter.EmitMulti
+func (self PublisherOnly) EmitMulti(events []string, args ...interface{}) PublisherOnly {
+	self.emitter.EmitMulti(events, args...)
+	return self
+}
@@ -0,0 +1,28 @@
+package Emitter
+
+import (
+	"testing"
+)
+
+func TestWriteOnly(t *testing.T) {
+	emitter := New()
+	producer := emitter.WriteOnly()
+
+	done := make(chan bool, 1)
+	events := []string{}
+	emitter.OnEvent("order.*", func(ev *Event) {
+		if ev.Name == "order.async" {
+			done <- true
+			return
+		}
+		events = append(events, ev.Name)
+	})
+
+	producer.EmitSync("order.created").
+		EmitEvent(&Event{Name: "order.paid"}).
+		EmitMulti([]string{"order.shipped", "order.closed"}).
+		EmitAsync("order.async", nil)
+	<-done
+	expect(t, 4, len(events))
+	expect(t, "order.closed", events[3])
+}
@@ -0,0 +1,259 @@
+// Package wsbridge forwards the events of an emitter to websocket clients and
+// optionally lets the clients emit events locally.
+//
+// The bridge doesn't depend on a websocket implementation, any connection
+// exposing ReadJSON/WriteJSON/Close (i.e *websocket.Conn of gorilla/websocket) works.
+//
+// Clients talk to the bridge using JSON messages:
+//
+//	{"type": "subscribe", "pattern": "user.*"}
+//	{"type": "unsubscribe", "pattern": "user.*"}
+//	{"type": "emit", "event": "user.created", "args": ["john"]}
+//
+// and receive the matching events as
+//
+//	{"type": "event", "event": "user.created", "args": ["john"]}
+//
+// The emitter's own "newListener"/"removeListener" meta-events are never forwarded.
+package wsbridge
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// Conn - a websocket connection
+type Conn interface {
+	ReadJSON(v interface{}) error
+	WriteJSON(v interface{}) error
+	Close() error
+}
+
+// Message - the message exchanged with the clients
+type Message struct {
+	Type    string        `json:"type"`
+	Event   string        `json:"event,omitempty"`
+	Pattern string        `json:"pattern,omitempty"`
+	Args    []interface{} `json:"args,omitempty"`
+}
+
+// ErrEmitForbidden - sent back to the clients emitting without permission
+var ErrEmitForbidden = errors.New("wsbridge: emit forbidden")
+
+// Bridge - the websocket adapter of an emitter
+type Bridge struct {
+	// Upgrade - upgrade the request to a websocket connection, required by ServeHTTP
+	Upgrade func(w http.ResponseWriter, r *http.Request) (Conn, error)
+	// Auth - authorize the request before upgrading it, a non nil error rejects the client,
+	// only consulted by ServeHTTP as connections passed to Serve are already established
+	Auth func(r *http.Request) error
+	// AllowEmit - decide whether the client may emit the event locally, clients can't emit if nil
+	AllowEmit func(c *Client, event string) bool
+	// Buffer - the count of events queued per client before the slow client starts losing events
+	Buffer int
+
+	emitter      *Emitter.Emitter
+	subscription *Emitter.Subscription
+	clients      map[*Client]bool
+	closed       bool
+	mutex        *sync.Mutex
+}
+
+// Client - a connected websocket client
+type Client struct {
+	// Request - the upgraded request, nil for connections passed to Serve
+	Request *http.Request
+
+	conn     Conn
+	patterns map[string]bool
+	events   chan Message
+	mutex    *sync.Mutex
+}
+
+// New() - create a new bridge forwarding the events matching the specified pattern
+func New(e *Emitter.Emitter, pattern string) *Bridge {
+	bridge := &Bridge{
+		Buffer:  64,
+		emitter: e,
+		clients: make(map[*Client]bool),
+		mutex:   &sync.Mutex{},
+	}
+	bridge.subscription = e.OnEvent(pattern, bridge.broadcast).Bridge()
+	return bridge
+}
+
+// Close() - stop forwarding the emitter's events, connected clients are disconnected and new ones rejected
+func (self *Bridge) Close() {
+	self.subscription.Remove()
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	self.closed = true
+	for c := range self.clients {
+		c.conn.Close()
+	}
+}
+
+// ServeHTTP() - authorize and upgrade the request then serve the connection
+func (self *Bridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if self.Upgrade == nil {
+		http.Error(w, "websocket upgrade unsupported", http.StatusInternalServerError)
+		return
+	}
+	if self.Closed() {
+		http.Error(w, "bridge closed", http.StatusServiceUnavailable)
+		return
+	}
+	if self.Auth != nil {
+		if err := self.Auth(r); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+
+	conn, err := self.Upgrade(w, r)
+	if err != nil {
+		return
+	}
+	self.serve(conn, r)
+}
+
+// Serve() - serve an already established connection until it is closed, Auth isn't consulted; the
+// connection is closed right away once the bridge is
+func (self *Bridge) Serve(conn Conn) {
+	self.serve(conn, nil)
+}
+
+// Closed() - whether the bridge was closed
+func (self *Bridge) Closed() bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	return self.closed
+}
+
+func (self *Bridge) serve(conn Conn, r *http.Request) {
+	c := &Client{
+		Request:  r,
+		conn:     conn,
+		patterns: make(map[string]bool),
+		events:   make(chan Message, self.Buffer),
+		mutex:    &sync.Mutex{},
+	}
+
+	self.mutex.Lock()
+	if self.closed {
+		self.mutex.Unlock()
+		conn.Close()
+		return
+	}
+	self.clients[c] = true
+	self.mutex.Unlock()
+
+	done := make(chan struct{})
+	go c.write(done)
+
+	defer func() {
+		self.mutex.Lock()
+		delete(self.clients, c)
+		self.mutex.Unlock()
+
+		close(c.events)
+		<-done
+		conn.Close()
+	}()
+
+	for {
+		var msg Message
+		if err := conn.ReadJSON(&msg); err != nil {
+			return
+		}
+		self.handle(c, msg)
+	}
+}
+
+// handle() - process a message received from the client
+func (self *Bridge) handle(c *Client, msg Message) {
+	switch msg.Type {
+	case "subscribe":
+		c.mutex.Lock()
+		c.patterns[msg.Pattern] = true
+		c.mutex.Unlock()
+
+	case "unsubscribe":
+		c.mutex.Lock()
+		delete(c.patterns, msg.Pattern)
+		c.mutex.Unlock()
+
+	case "emit":
+		if self.AllowEmit == nil || !self.AllowEmit(c, msg.Event) {
+			c.send(Message{Type: "error", Event: msg.Event, Args: []interface{}{ErrEmitForbidden.Error()}})
+			return
+		}
+		self.emitter.EmitSync(msg.Event, msg.Args...)
+	}
+}
+
+// Patterns() - return the patterns the client is subscribed to
+func (self *Client) Patterns() []string {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	patterns := make([]string, 0, len(self.patterns))
+	for pattern := range self.patterns {
+		patterns = append(patterns, pattern)
+	}
+	return patterns
+}
+
+// subscribed() - whether the client subscribed to the event
+func (self *Client) subscribed(event string) bool {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for pattern := range self.patterns {
+		if Emitter.Match(pattern, event) {
+			return true
+		}
+	}
+	return false
+}
+
+// send() - queue the message, dropping it if the client is too slow
+func (self *Client) send(msg Message) {
+	select {
+	case self.events <- msg:
+	default:
+	}
+}
+
+// write() - write the queued messages to the connection, the only writer of the connection,
+// a failed write closes the connection which ends the client's read loop
+func (self *Client) write(done chan struct{}) {
+	defer close(done)
+	for msg := range self.events {
+		if err := self.conn.WriteJSON(msg); err != nil {
+			self.conn.Close()
+			return
+		}
+	}
+}
+
+// broadcast() - queue the event on every client subscribed to it
+func (self *Bridge) broadcast(ev *Emitter.Event) {
+	if Emitter.IsMetaEvent(ev.Name) {
+		return
+	}
+
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+
+	for c := range self.clients {
+		if c.subscribed(ev.Name) {
+			c.send(Message{Type: "event", Event: ev.Name, Args: ev.Args})
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package wsbridge
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	Emitter "github.com/moleculer-go/goemitter"
+)
+
+// fakeConn - an in memory connection, `in` feeds the bridge and `out` collects what it writes
+type fakeConn struct {
+	in       chan Message
+	out      chan Message
+	writeErr error
+	writes   int
+	closes   int
+	closed   chan struct{}
+	mutex    sync.Mutex
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{in: make(chan Message), out: make(chan Message, 10), closed: make(chan struct{})}
+}
+
+func (self *fakeConn) ReadJSON(v interface{}) error {
+	select {
+	case msg, ok := <-self.in:
+		if !ok {
+			return io.EOF
+		}
+		data, _ := json.Marshal(msg)
+		return json.Unmarshal(data, v)
+	case <-self.closed:
+		return io.EOF
+	}
+}
+
+func (self *fakeConn) WriteJSON(v interface{}) error {
+	self.mutex.Lock()
+	self.writes++
+	err := self.writeErr
+	self.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	self.out <- v.(Message)
+	return nil
+}
+
+func (self *fakeConn) Close() error {
+	self.mutex.Lock()
+	defer self.mutex.Unlock()
+	if self.closes == 0 {
+		close(self.closed)
+	}
+	self.closes++
+	return nil
+}
+
+func TestBridge(t *testing.T) {
+	emitter := Emitter.Construct()
+	bridge := New(emitter, "**")
+	bridge.AllowEmit = func(c *Client, event string) bool {
+		return event == "chat.message"
+	}
+
+	received := make(chan []interface{}, 1)
+	emitter.On("chat.message", func(args ...interface{}) {
+		received <- args
+	})
+
+	conn := newFakeConn()
+	go bridge.Serve(conn)
+	defer close(conn.in)
+
+	conn.in <- Message{Type: "subscribe", Pattern: "user.*"}
+	conn.in <- Message{Type: "emit", Event: "chat.message", Args: []interface{}{"hello"}}
+
+	select {
+	case args := <-received:
+		expect(t, "hello", args[0])
+	case <-time.After(time.Second):
+		t.Fatal("client emit not received")
+	}
+
+	emitter.EmitSync("account.created", "john")
+	emitter.EmitSync("user.created", "john")
+
+	msg := <-conn.out
+	expect(t, "event", msg.Type)
+	expect(t, "user.created", msg.Event)
+
+	conn.in <- Message{Type: "emit", Event: "user.deleted"}
+	msg = <-conn.out
+	expect(t, "error", msg.Type)
+	expect(t, ErrEmitForbidden.Error(), msg.Args[0])
+}
+
+func TestBridgeAuth(t *testing.T) {
+	bridge := New(Emitter.Construct(), "**")
+	defer bridge.Close()
+
+	upgraded := false
+	bridge.Upgrade = func(w http.ResponseWriter, r *http.Request) (Conn, error) {
+		upgraded = true
+		return nil, errors.New("unexpected upgrade")
+	}
+	bridge.Auth = func(r *http.Request) error {
+		return errors.New("unauthorized")
+	}
+
+	w := httptest.NewRecorder()
+	bridge.ServeHTTP(w, httptest.NewRequest("GET", "/ws", nil))
+
+	expect(t, http.StatusUnauthorized, w.Code)
+	expect(t, false, upgraded)
+}
+
+func TestBridgeWriteError(t *testing.T) {
+	emitter := Emitter.Construct()
+	bridge := New(emitter, "**")
+	defer bridge.Close()
+
+	conn := newFakeConn()
+	conn.writeErr = errors.New("broken pipe")
+
+	done := make(chan struct{})
+	go func() {
+		bridge.Serve(conn)
+		close(done)
+	}()
+	conn.in <- Message{Type: "subscribe", Pattern: "**"}
+
+	emitter.EmitSync("user.created")
+	emitter.EmitSync("user.created")
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("failed write didn't end the connection")
+	}
+
+	conn.mutex.Lock()
+	defer conn.mutex.Unlock()
+	expect(t, 1, conn.writes)
+}
+
+func TestBridgeClose(t *testing.T) {
+	emitter := Emitter.Construct()
+	bridge := New(emitter, "user.*")
+
+	conn := newFakeConn()
+	done := make(chan struct{})
+	go func() {
+		bridge.Serve(conn)
+		close(done)
+	}()
+	conn.in <- Message{Type: "subscribe", Pattern: "**"}
+
+	bridge.Close()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Close didn't disconnect the client")
+	}
+	expect(t, 0, emitter.ListenersCount("user.created"))
+
+	// the clients connecting afterwards are rejected
+	late := newFakeConn()
+	bridge.Serve(late)
+	expect(t, 1, late.closes)
+
+	upgraded := false
+	bridge.Upgrade = func(w http.ResponseWriter, r *http.Request) (Conn, error) {
+		upgraded = true
+		return newFakeConn(), nil
+	}
+	w := httptest.NewRecorder()
+	bridge.ServeHTTP(w, httptest.NewRequest("GET", "/ws", nil))
+	expect(t, http.StatusServiceUnavailable, w.Code)
+	expect(t, false, upgraded)
+}
+
+func expect(t *testing.T, a interface{}, b interface{}) {
+	if a != b {
+		t.Errorf("Expected %v - Got %v", a, b)
+	}
+}